@@ -1,14 +1,20 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // ClusterObjectRef references an object by name, namespace, and cluster.
@@ -29,20 +35,118 @@ func (o ClusterObjectRef) String() string {
 	return strings.Join([]string{o.ClusterID, o.Namespace, o.Name}, string(types.Separator))
 }
 
-// ObjectRef references a namespace-scoped object by name and namespace.
+// ParseClusterObjectRef parses a ClusterObjectRef from the format produced by ClusterObjectRef.String(),
+// i.e. "<clusterID>/<namespace>/<name>". All three components are required.
+func ParseClusterObjectRef(s string) (ClusterObjectRef, error) {
+	parts := strings.Split(s, string(types.Separator))
+	if len(parts) != 3 {
+		return ClusterObjectRef{}, fmt.Errorf("malformed cluster object ref %q: expected 3 %q-separated components, got %d", s, types.Separator, len(parts))
+	}
+	for i, component := range []string{"cluster ID", "namespace", "name"} {
+		if parts[i] == "" {
+			return ClusterObjectRef{}, fmt.Errorf("malformed cluster object ref %q: empty %s", s, component)
+		}
+	}
+
+	return ClusterObjectRef{
+		ClusterID: parts[0],
+		Namespace: parts[1],
+		Name:      parts[2],
+	}, nil
+}
+
+// Equal returns true if the ClusterObjectRef is identical to the supplied ClusterObjectRef.
+func (o ClusterObjectRef) Equal(other ClusterObjectRef) bool {
+	return o == other
+}
+
+// ToRequest returns the ClusterObjectRef as a reconcile.Request. The ClusterID is not part of the request
+// and must be tracked out-of-band by the caller, e.g. by a per-cluster work queue.
+func (o ClusterObjectRef) ToRequest() reconcile.Request {
+	return reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: o.Namespace,
+			Name:      o.Name,
+		},
+	}
+}
+
+// ClusterObjectRefFromRequest returns a ClusterObjectRef from a reconcile.Request and the ID of the cluster it was
+// enqueued for. It is the inverse of ClusterObjectRef.ToRequest.
+func ClusterObjectRefFromRequest(clusterID string, req reconcile.Request) ClusterObjectRef {
+	return ClusterObjectRef{
+		Name:      req.Name,
+		Namespace: req.Namespace,
+		ClusterID: clusterID,
+	}
+}
+
+// ObjectRef references an object by name and namespace.
+// An empty namespace references a cluster-scoped object.
 type ObjectRef struct {
 	// Name of the object. Required.
 	Name string `json:"name"`
 
-	// Namespace of the object. Required.
+	// Namespace of the object. Required for namespace-scoped objects, empty for cluster-scoped objects.
 	Namespace string `json:"namespace"`
 }
 
-// ObjectKey returns the ObjectRef as a client.ObjectKey
+// ObjectKey returns the ObjectRef as a client.ObjectKey. The key of a cluster-scoped ref has an empty namespace.
 func (o ObjectRef) ObjectKey() client.ObjectKey {
 	return client.ObjectKey{Namespace: o.Namespace, Name: o.Name}
 }
 
+// IsClusterScoped returns true if the ObjectRef references a cluster-scoped object, i.e. its namespace is empty.
+func (o ObjectRef) IsClusterScoped() bool {
+	return o.Namespace == ""
+}
+
+// String returns the ObjectRef as "<namespace>/<name>", or as "<name>" if it is cluster-scoped.
+// The two forms are distinguishable because object names cannot contain a "/".
+func (o ObjectRef) String() string {
+	if o.IsClusterScoped() {
+		return o.Name
+	}
+	return o.ObjectKey().String()
+}
+
+// ParseObjectRef parses an ObjectRef from the format produced by ObjectRef.String().
+func ParseObjectRef(s string) (ObjectRef, error) {
+	parts := strings.Split(s, string(types.Separator))
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		return ObjectRef{Name: parts[0]}, nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return ObjectRef{Namespace: parts[0], Name: parts[1]}, nil
+	default:
+		return ObjectRef{}, fmt.Errorf("malformed object ref %q: expected \"<name>\" or \"<namespace>/<name>\"", s)
+	}
+}
+
+// Equal returns true if the ObjectRef is identical to the supplied ObjectRef.
+func (o ObjectRef) Equal(other ObjectRef) bool {
+	return o == other
+}
+
+// Validate returns an error if the ObjectRef has no name. The namespace may be empty for cluster-scoped refs.
+func (o ObjectRef) Validate() error {
+	if o.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+// WithGVK returns a TypedObjectRef referencing the same object with the supplied Group, Version, and Kind.
+func (o ObjectRef) WithGVK(gvk schema.GroupVersionKind) TypedObjectRef {
+	return TypedObjectRef{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+}
+
 // ObjectRefFrom returns an *ObjectRef from a client.Object
 func ObjectRefFrom(o client.Object) *ObjectRef {
 	return &ObjectRef{
@@ -51,6 +155,22 @@ func ObjectRefFrom(o client.Object) *ObjectRef {
 	}
 }
 
+// TypedObjectRefFromObject returns a *TypedObjectRef from a client.Object, resolving its Group, Version, and Kind
+// via the scheme. Returns an error if the object's type is not registered in the scheme.
+func TypedObjectRefFromObject(o client.Object, scheme *runtime.Scheme) (*TypedObjectRef, error) {
+	gvk, err := apiutil.GVKForObject(o, scheme)
+	if err != nil {
+		return nil, fmt.Errorf("getting GVK for object %s: %w", client.ObjectKeyFromObject(o), err)
+	}
+	return &TypedObjectRef{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Name:      o.GetName(),
+		Namespace: o.GetNamespace(),
+	}, nil
+}
+
 // TypedObjectRef references an object by name and namespace and includes its Group, Version, and Kind.
 type TypedObjectRef struct {
 
@@ -66,7 +186,7 @@ type TypedObjectRef struct {
 	// Name of the object. Required.
 	Name string `json:"name"`
 
-	// Namespace of the object. Required.
+	// Namespace of the object. Required for namespace-scoped objects, empty for cluster-scoped objects.
 	Namespace string `json:"namespace"`
 }
 
@@ -89,6 +209,14 @@ func (t TypedObjectRef) ObjectKeyNotSet() bool {
 	return t.Name == "" && t.Namespace == ""
 }
 
+// ObjectRef returns an ObjectRef referencing the same object, dropping the Group, Version, and Kind.
+func (t TypedObjectRef) ObjectRef() ObjectRef {
+	return ObjectRef{
+		Name:      t.Name,
+		Namespace: t.Namespace,
+	}
+}
+
 // ToCoreV1ObjectReference is a convenience method that returns a *corev1.ObjectReference with a subset of fields populated.
 func (t TypedObjectRef) ToCoreV1ObjectReference() *corev1.ObjectReference {
 	return &corev1.ObjectReference{
@@ -102,10 +230,148 @@ func (t TypedObjectRef) ToCoreV1ObjectReference() *corev1.ObjectReference {
 	}
 }
 
+// CouldOwn returns true if the object referenced by t may be set as an owner of the object referenced by other,
+// per Kubernetes' owner reference rules: a cluster-scoped owner may own any object, while a namespaced owner may only
+// own objects in its own namespace. Cross-namespace ownership, and a namespaced owner of a cluster-scoped object,
+// are rejected.
+func (t TypedObjectRef) CouldOwn(other TypedObjectRef) bool {
+	return t.Namespace == "" || t.Namespace == other.Namespace
+}
+
+// ToUnstructured is a convenience method that returns an *unstructured.Unstructured with its GVK, name, and namespace
+// set from the TypedObjectRef. Callers typically pass the result to client.Get to fetch the referenced object.
+func (t TypedObjectRef) ToUnstructured() *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(t.GroupVersionKind())
+	u.SetName(t.Name)
+	u.SetNamespace(t.Namespace)
+	return u
+}
+
 func (t TypedObjectRef) String() string {
 	return fmt.Sprintf("%s: %s", t.GroupVersionKind(), t.ObjectKey())
 }
 
+// Equal returns true if the TypedObjectRef is identical to the supplied TypedObjectRef.
+func (t TypedObjectRef) Equal(other TypedObjectRef) bool {
+	return t == other
+}
+
+// Validate returns an error listing each required field of the TypedObjectRef that is empty.
+// Group may be empty since core types have no group, and Namespace may be empty for cluster-scoped refs.
+// Use ValidateScope to check the namespace against the scope of the ref's kind.
+func (t TypedObjectRef) Validate() error {
+	var errs []error
+	if t.Version == "" {
+		errs = append(errs, errors.New("version is required"))
+	}
+	if t.Kind == "" {
+		errs = append(errs, errors.New("kind is required"))
+	}
+	if t.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	return errors.Join(errs...)
+}
+
+// Less returns true if the TypedObjectRef orders before the supplied TypedObjectRef,
+// comparing by Group, Version, Kind, Namespace, then Name.
+func (t TypedObjectRef) Less(other TypedObjectRef) bool {
+	if t.Group != other.Group {
+		return t.Group < other.Group
+	}
+	if t.Version != other.Version {
+		return t.Version < other.Version
+	}
+	if t.Kind != other.Kind {
+		return t.Kind < other.Kind
+	}
+	if t.Namespace != other.Namespace {
+		return t.Namespace < other.Namespace
+	}
+	return t.Name < other.Name
+}
+
+// ParseTypedObjectRefString parses a TypedObjectRef from the format produced by TypedObjectRef.String(),
+// i.e. "<group>/<version>, Kind=<kind>: <namespace>/<name>". The group and namespace may be empty.
+func ParseTypedObjectRefString(s string) (TypedObjectRef, error) {
+	gv, rest, ok := strings.Cut(s, ", Kind=")
+	if !ok {
+		return TypedObjectRef{}, fmt.Errorf("malformed typed object ref %q: missing kind", s)
+	}
+	group, version, ok := strings.Cut(gv, "/")
+	if !ok {
+		return TypedObjectRef{}, fmt.Errorf("malformed typed object ref %q: missing group/version separator", s)
+	}
+	kind, key, ok := strings.Cut(rest, ": ")
+	if !ok {
+		return TypedObjectRef{}, fmt.Errorf("malformed typed object ref %q: missing object key", s)
+	}
+	namespace, name, ok := strings.Cut(key, string(types.Separator))
+	if !ok {
+		return TypedObjectRef{}, fmt.Errorf("malformed typed object ref %q: missing namespace/name separator", s)
+	}
+
+	if version == "" || kind == "" || name == "" {
+		return TypedObjectRef{}, fmt.Errorf("malformed typed object ref %q: version, kind, and name must be non-empty", s)
+	}
+
+	return TypedObjectRef{
+		Group:     group,
+		Version:   version,
+		Kind:      kind,
+		Name:      name,
+		Namespace: namespace,
+	}, nil
+}
+
+// ValidateScope returns an error if the TypedObjectRef's namespace is inconsistent with the scope of its kind as
+// reported by the supplied RESTMapper, i.e. if a cluster-scoped ref has a namespace or a namespace-scoped ref does not.
+func (t TypedObjectRef) ValidateScope(mapper meta.RESTMapper) error {
+	gvk := t.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return fmt.Errorf("getting REST mapping for %s: %w", gvk, err)
+	}
+
+	clusterScoped := mapping.Scope.Name() == meta.RESTScopeNameRoot
+	if clusterScoped && t.Namespace != "" {
+		return fmt.Errorf("%s is cluster-scoped but ref has namespace %q", gvk, t.Namespace)
+	}
+	if !clusterScoped && t.Namespace == "" {
+		return fmt.Errorf("%s is namespace-scoped but ref has no namespace", gvk)
+	}
+
+	return nil
+}
+
+// TypedClusterObjectRef references a typed object by Group, Version, Kind, name, namespace, and cluster.
+// Used in multi-cluster APIs.
+type TypedClusterObjectRef struct {
+	TypedObjectRef `json:",inline"`
+
+	// ClusterID of the object. Required.
+	ClusterID string `json:"clusterId"`
+}
+
+func (t TypedClusterObjectRef) String() string {
+	return fmt.Sprintf("%s: %s", t.ClusterID, t.TypedObjectRef)
+}
+
+// Equal returns true if the TypedClusterObjectRef is identical to the supplied TypedClusterObjectRef.
+func (t TypedClusterObjectRef) Equal(other TypedClusterObjectRef) bool {
+	return t == other
+}
+
+// Less returns true if the TypedClusterObjectRef orders before the supplied TypedClusterObjectRef,
+// comparing by ClusterID and then as a TypedObjectRef.
+func (t TypedClusterObjectRef) Less(other TypedClusterObjectRef) bool {
+	if t.ClusterID != other.ClusterID {
+		return t.ClusterID < other.ClusterID
+	}
+	return t.TypedObjectRef.Less(other.TypedObjectRef)
+}
+
 // NamedObjectRef references an object by name and optionally by namespace.
 type NamedObjectRef struct {
 	// Name of the object. Required.
@@ -114,3 +380,31 @@ type NamedObjectRef struct {
 	// Namespace of the object. Optional. Defaulting behavior is determined by the parent API.
 	Namespace string `json:"namespace,omitempty"`
 }
+
+// WithDefaultNamespace returns an ObjectRef referencing the same object, using defaultNS if the namespace is empty.
+func (n NamedObjectRef) WithDefaultNamespace(defaultNS string) ObjectRef {
+	ns := n.Namespace
+	if ns == "" {
+		ns = defaultNS
+	}
+	return ObjectRef{Name: n.Name, Namespace: ns}
+}
+
+// ObjectKey returns the NamedObjectRef as a client.ObjectKey, using defaultNS if the namespace is empty.
+func (n NamedObjectRef) ObjectKey(defaultNS string) client.ObjectKey {
+	return n.WithDefaultNamespace(defaultNS).ObjectKey()
+}
+
+// ValidateNamedObjectRefsUnique returns an error if any two refs resolve to the same object key after defaulting
+// empty namespaces to defaultNamespace.
+func ValidateNamedObjectRefsUnique(refs []NamedObjectRef, defaultNamespace string) error {
+	seen := make(map[client.ObjectKey]struct{}, len(refs))
+	for _, ref := range refs {
+		key := ref.ObjectKey(defaultNamespace)
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("duplicate object reference %s", key)
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,374 @@
+package api
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestClusterObjectRefRequestRoundTrip(t *testing.T) {
+	ref := ClusterObjectRef{ClusterID: "cluster-a", Namespace: "default", Name: "foo"}
+
+	req := ref.ToRequest()
+	if req.Namespace != "default" || req.Name != "foo" {
+		t.Errorf("ToRequest() = %v, want default/foo", req)
+	}
+	if got := ClusterObjectRefFromRequest("cluster-a", req); got != ref {
+		t.Errorf("ClusterObjectRefFromRequest(ToRequest()) = %v, want %v", got, ref)
+	}
+}
+
+var (
+	configMapGVK = schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	namespaceGVK = schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}
+)
+
+func TestTypedObjectRefValidateScope(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(configMapGVK, meta.RESTScopeNamespace)
+	mapper.Add(namespaceGVK, meta.RESTScopeRoot)
+
+	tests := []struct {
+		name    string
+		ref     TypedObjectRef
+		wantErr bool
+	}{
+		{
+			name: "namespaced ref with namespace",
+			ref:  TypedObjectRef{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "foo"},
+		},
+		{
+			name:    "namespaced ref without namespace",
+			ref:     TypedObjectRef{Version: "v1", Kind: "ConfigMap", Name: "foo"},
+			wantErr: true,
+		},
+		{
+			name: "cluster-scoped ref without namespace",
+			ref:  TypedObjectRef{Version: "v1", Kind: "Namespace", Name: "foo"},
+		},
+		{
+			name:    "cluster-scoped ref with namespace",
+			ref:     TypedObjectRef{Version: "v1", Kind: "Namespace", Namespace: "default", Name: "foo"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown kind",
+			ref:     TypedObjectRef{Group: "example.com", Version: "v1", Kind: "Widget", Namespace: "default", Name: "foo"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.ref.ValidateScope(mapper); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateScope() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateNamedObjectRefsUnique(t *testing.T) {
+	tests := []struct {
+		name    string
+		refs    []NamedObjectRef
+		wantErr bool
+	}{
+		{
+			name: "unique",
+			refs: []NamedObjectRef{{Name: "foo"}, {Name: "bar"}},
+		},
+		{
+			name: "same name in different namespaces",
+			refs: []NamedObjectRef{{Name: "foo", Namespace: "ns-a"}, {Name: "foo", Namespace: "ns-b"}},
+		},
+		{
+			name:    "duplicate",
+			refs:    []NamedObjectRef{{Name: "foo", Namespace: "ns-a"}, {Name: "foo", Namespace: "ns-a"}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate after defaulting",
+			refs:    []NamedObjectRef{{Name: "foo"}, {Name: "foo", Namespace: "default"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateNamedObjectRefsUnique(tt.refs, "default"); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateNamedObjectRefsUnique() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseTypedObjectRefString(t *testing.T) {
+	refs := map[string]TypedObjectRef{
+		"core group":     {Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "foo"},
+		"named group":    {Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "foo"},
+		"cluster-scoped": {Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole", Name: "admin"},
+	}
+	for name, ref := range refs {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseTypedObjectRefString(ref.String())
+			if err != nil {
+				t.Fatalf("ParseTypedObjectRefString(%q) error = %v", ref.String(), err)
+			}
+			if got != ref {
+				t.Errorf("ParseTypedObjectRefString(%q) = %#v, want %#v", ref.String(), got, ref)
+			}
+		})
+	}
+
+	for _, s := range []string{
+		"",
+		"/v1: default/foo",
+		"v1, Kind=ConfigMap: default/foo",
+		"/v1, Kind=ConfigMap default/foo",
+		"/v1, Kind=ConfigMap: foo",
+		"/, Kind=ConfigMap: default/foo",
+		"/v1, Kind=: default/foo",
+		"/v1, Kind=ConfigMap: default/",
+	} {
+		if _, err := ParseTypedObjectRefString(s); err == nil {
+			t.Errorf("ParseTypedObjectRefString(%q) succeeded, want error", s)
+		}
+	}
+}
+
+func TestParseClusterObjectRef(t *testing.T) {
+	want := ClusterObjectRef{ClusterID: "west", Namespace: "default", Name: "foo"}
+	got, err := ParseClusterObjectRef(want.String())
+	if err != nil {
+		t.Fatalf("ParseClusterObjectRef(%q) error = %v", want.String(), err)
+	}
+	if got != want {
+		t.Errorf("ParseClusterObjectRef(%q) = %+v, want %+v", want.String(), got, want)
+	}
+
+	for _, s := range []string{"", "west/foo", "west/default/foo/bar", "/default/foo", "west//foo", "west/default/"} {
+		if _, err := ParseClusterObjectRef(s); err == nil {
+			t.Errorf("ParseClusterObjectRef(%q) succeeded, want error", s)
+		}
+	}
+}
+
+func TestTypedObjectRefEqualAndLess(t *testing.T) {
+	a := TypedObjectRef{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "a"}
+	b := TypedObjectRef{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "b"}
+	deployment := TypedObjectRef{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "a"}
+
+	if !a.Equal(a) || a.Equal(b) || b.Equal(a) {
+		t.Error("Equal() is not reflexive and symmetric")
+	}
+
+	refs := []TypedObjectRef{deployment, b, a}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].Less(refs[j]) })
+	if want := []TypedObjectRef{a, b, deployment}; !reflect.DeepEqual(refs, want) {
+		t.Errorf("sorted refs = %v, want %v", refs, want)
+	}
+	if a.Less(a) {
+		t.Error("Less() = true for equal refs")
+	}
+
+	clusterA := TypedClusterObjectRef{TypedObjectRef: deployment, ClusterID: "east"}
+	clusterB := TypedClusterObjectRef{TypedObjectRef: a, ClusterID: "west"}
+	if !clusterA.Less(clusterB) || clusterB.Less(clusterA) {
+		t.Error("TypedClusterObjectRef.Less() does not order by cluster first")
+	}
+	if !clusterA.Equal(clusterA) || clusterA.Equal(clusterB) {
+		t.Error("TypedClusterObjectRef.Equal() is not reflexive and symmetric")
+	}
+	if (ObjectRef{Namespace: "default", Name: "a"}).Equal(ObjectRef{Name: "a"}) {
+		t.Error("ObjectRef.Equal() = true for refs in different namespaces")
+	}
+}
+
+func TestTypedObjectRefFromObject(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"}}
+
+	got, err := TypedObjectRefFromObject(cm, scheme)
+	if err != nil {
+		t.Fatalf("TypedObjectRefFromObject() error = %v", err)
+	}
+	if want := (TypedObjectRef{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "foo"}); *got != want {
+		t.Errorf("TypedObjectRefFromObject() = %+v, want %+v", *got, want)
+	}
+
+	if _, err := TypedObjectRefFromObject(cm, runtime.NewScheme()); err == nil {
+		t.Error("TypedObjectRefFromObject() succeeded for a type not registered in the scheme")
+	}
+}
+
+func TestTypedObjectRefToUnstructured(t *testing.T) {
+	ref := TypedObjectRef{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "foo"}
+
+	u := ref.ToUnstructured()
+	if got := u.GroupVersionKind(); got != ref.GroupVersionKind() {
+		t.Errorf("GVK = %v, want %v", got, ref.GroupVersionKind())
+	}
+	if u.GetNamespace() != "default" || u.GetName() != "foo" {
+		t.Errorf("object key = %v/%v, want default/foo", u.GetNamespace(), u.GetName())
+	}
+	if got := u.GetAPIVersion(); got != "apps/v1" {
+		t.Errorf("apiVersion = %q, want \"apps/v1\"", got)
+	}
+}
+
+func TestTypedObjectRefCouldOwn(t *testing.T) {
+	namespaced := TypedObjectRef{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "owner"}
+	clusterScoped := TypedObjectRef{Version: "v1", Kind: "Namespace", Name: "owner"}
+
+	tests := []struct {
+		name         string
+		owner, owned TypedObjectRef
+		want         bool
+	}{
+		{name: "same namespace", owner: namespaced, owned: TypedObjectRef{Namespace: "default", Name: "owned"}, want: true},
+		{name: "cross namespace", owner: namespaced, owned: TypedObjectRef{Namespace: "other", Name: "owned"}, want: false},
+		{name: "namespaced owner of cluster-scoped", owner: namespaced, owned: TypedObjectRef{Name: "owned"}, want: false},
+		{name: "cluster-scoped owner of namespaced", owner: clusterScoped, owned: TypedObjectRef{Namespace: "default", Name: "owned"}, want: true},
+		{name: "cluster-scoped owner of cluster-scoped", owner: clusterScoped, owned: TypedObjectRef{Name: "owned"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.owner.CouldOwn(tt.owned); got != tt.want {
+				t.Errorf("CouldOwn() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypedObjectRefValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ref     TypedObjectRef
+		wantErr []string
+	}{
+		{
+			name: "complete",
+			ref:  TypedObjectRef{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "foo"},
+		},
+		{
+			name: "cluster-scoped core type",
+			ref:  TypedObjectRef{Version: "v1", Kind: "Namespace", Name: "foo"},
+		},
+		{
+			name:    "missing kind",
+			ref:     TypedObjectRef{Version: "v1", Namespace: "default", Name: "foo"},
+			wantErr: []string{"kind is required"},
+		},
+		{
+			name:    "empty",
+			wantErr: []string{"version is required", "kind is required", "name is required"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ref.Validate()
+			if (err != nil) != (len(tt.wantErr) > 0) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() error = %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestObjectRefClusterScoped(t *testing.T) {
+	tests := []struct {
+		name              string
+		ref               ObjectRef
+		wantString        string
+		wantClusterScoped bool
+	}{
+		{name: "namespaced", ref: ObjectRef{Namespace: "default", Name: "foo"}, wantString: "default/foo"},
+		{name: "cluster-scoped", ref: ObjectRef{Name: "bar"}, wantString: "bar", wantClusterScoped: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ref.String(); got != tt.wantString {
+				t.Errorf("String() = %q, want %q", got, tt.wantString)
+			}
+			if got := tt.ref.IsClusterScoped(); got != tt.wantClusterScoped {
+				t.Errorf("IsClusterScoped() = %t, want %t", got, tt.wantClusterScoped)
+			}
+			if got := tt.ref.ObjectKey(); got.Namespace != tt.ref.Namespace || got.Name != tt.ref.Name {
+				t.Errorf("ObjectKey() = %v, want %v", got, tt.wantString)
+			}
+			if err := tt.ref.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+
+			parsed, err := ParseObjectRef(tt.wantString)
+			if err != nil {
+				t.Fatalf("ParseObjectRef(%q) error = %v", tt.wantString, err)
+			}
+			if parsed != tt.ref {
+				t.Errorf("ParseObjectRef(%q) = %+v, want %+v", tt.wantString, parsed, tt.ref)
+			}
+		})
+	}
+
+	for _, s := range []string{"", "/foo", "default/", "a/b/c"} {
+		if _, err := ParseObjectRef(s); err == nil {
+			t.Errorf("ParseObjectRef(%q) succeeded, want error", s)
+		}
+	}
+}
+
+func TestReferencesInvalidMessage(t *testing.T) {
+	c := ReferencesInvalid(ReasonReferenceNotFound, []ObjectRef{{Namespace: "default", Name: "foo"}, {Name: "bar"}})
+	if want := "Referenced objects are not found: default/foo, bar"; c.Message != want {
+		t.Errorf("ReferencesInvalid() message = %q, want %q", c.Message, want)
+	}
+}
+
+func TestObjectRefTypedObjectRefConversion(t *testing.T) {
+	ref := ObjectRef{Namespace: "default", Name: "foo"}
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	typed := ref.WithGVK(gvk)
+	if want := (TypedObjectRef{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "foo"}); typed != want {
+		t.Errorf("WithGVK() = %+v, want %+v", typed, want)
+	}
+	if got := typed.ObjectRef(); got != ref {
+		t.Errorf("ObjectRef() = %+v, want %+v", got, ref)
+	}
+	if ref != (ObjectRef{Namespace: "default", Name: "foo"}) {
+		t.Error("WithGVK() modified the receiver")
+	}
+}
+
+func TestNamedObjectRefWithDefaultNamespace(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  NamedObjectRef
+		want ObjectRef
+	}{
+		{name: "namespace set", ref: NamedObjectRef{Namespace: "other", Name: "foo"}, want: ObjectRef{Namespace: "other", Name: "foo"}},
+		{name: "namespace defaulted", ref: NamedObjectRef{Name: "foo"}, want: ObjectRef{Namespace: "default", Name: "foo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ref.WithDefaultNamespace("default"); got != tt.want {
+				t.Errorf("WithDefaultNamespace() = %+v, want %+v", got, tt.want)
+			}
+			if got := tt.ref.ObjectKey("default"); got != tt.want.ObjectKey() {
+				t.Errorf("ObjectKey() = %v, want %v", got, tt.want.ObjectKey())
+			}
+		})
+	}
+}
@@ -1,12 +1,24 @@
 package api
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // A Conditioned may have conditions set or retrieved. Conditions
@@ -24,6 +36,20 @@ type Conditioned interface {
 	GetCondition(ConditionType) Condition
 }
 
+// A ConditionRemover may have conditions removed. It is implemented by ConditionedStatus
+// and allows generic FSM code to clean up conditions that no longer apply.
+type ConditionRemover interface {
+	// RemoveConditions removes the status conditions of the resource with the supplied types.
+	RemoveConditions(types ...ConditionType)
+}
+
+// A ConditionedStatusProvider exposes the ConditionedStatus backing a Conditioned. It is typically implemented by
+// resources that embed a ConditionedStatus in their status.
+type ConditionedStatusProvider interface {
+	// GetConditionedStatus returns the status conditions of the resource.
+	GetConditionedStatus() *ConditionedStatus
+}
+
 // A ConditionType represents a condition a resource could be in.
 type ConditionType string
 
@@ -46,6 +72,12 @@ const (
 
 	// ReasonReferencesExist is the reason that ReferencesValid is true.
 	ReasonReferencesExist = "ReferencedObjectsExist"
+
+	// TypeManagedResourcesHealthy indicates whether all managed resources tracked by the resource still exist.
+	TypeManagedResourcesHealthy ConditionType = "ManagedResourcesHealthy"
+
+	// ReasonManagedResourcesMissing is the reason that ManagedResourcesHealthy is false.
+	ReasonManagedResourcesMissing ConditionReason = "ManagedResourcesMissing"
 )
 
 // A ConditionReason represents the reason a resource is in a condition.
@@ -57,12 +89,73 @@ const (
 	ReasonUnavailable ConditionReason = "Unavailable"
 	ReasonCreating    ConditionReason = "Creating"
 	ReasonDeleting    ConditionReason = "Deleting"
+
+	ReasonQuotaExceeded   ConditionReason = "QuotaExceeded"
+	ReasonAdmissionDenied ConditionReason = "AdmissionDenied"
+
+	ReasonNothingToAggregate ConditionReason = "NothingToAggregate"
 )
 
 // Reasons a resource is or is not synced.
 const (
 	ReasonReconcileSuccess ConditionReason = "ReconcileSuccess"
 	ReasonReconcileError   ConditionReason = "ReconcileError"
+	ReasonReconcilePending ConditionReason = "ReconcilePending"
+
+	ReasonReferenceNotFound ConditionReason = "ReferenceNotFound"
+	ReasonConflict          ConditionReason = "Conflict"
+	ReasonForbidden         ConditionReason = "Forbidden"
+	ReasonTimeout           ConditionReason = "Timeout"
+	ReasonInvalid           ConditionReason = "Invalid"
+)
+
+// Reasons a rolled-up condition is or is not complete.
+const (
+	ReasonRollupComplete   ConditionReason = "RollupComplete"
+	ReasonRollupIncomplete ConditionReason = "RollupIncomplete"
+)
+
+// StandardReasons returns all condition reasons defined by this package.
+func StandardReasons() []ConditionReason {
+	return []ConditionReason{
+		ReasonAvailable,
+		ReasonUnavailable,
+		ReasonCreating,
+		ReasonDeleting,
+		ReasonQuotaExceeded,
+		ReasonAdmissionDenied,
+		ReasonNothingToAggregate,
+		ReasonReconcileSuccess,
+		ReasonReconcileError,
+		ReasonReconcilePending,
+		ReasonReferenceNotFound,
+		ReasonConflict,
+		ReasonForbidden,
+		ReasonTimeout,
+		ReasonInvalid,
+		ReasonRollupComplete,
+		ReasonRollupIncomplete,
+		ReasonReferencesExist,
+		ReasonManagedResourcesMissing,
+	}
+}
+
+// DefaultTerminalReasons returns the reasons defined by this package for which retrying reconciliation is not
+// expected to succeed without a change to the resource. A new slice is returned on each call.
+func DefaultTerminalReasons() []ConditionReason {
+	return []ConditionReason{ReasonInvalid}
+}
+
+// A ConditionSeverity represents how serious a condition is when it is not in its healthy state.
+type ConditionSeverity string
+
+// Condition severities.
+const (
+	// SeverityNone is the default severity for conditions that don't specify one.
+	SeverityNone    ConditionSeverity = ""
+	SeverityError   ConditionSeverity = "Error"
+	SeverityWarning ConditionSeverity = "Warning"
+	SeverityInfo    ConditionSeverity = "Info"
 )
 
 // A Condition that may apply to a resource.
@@ -92,16 +185,69 @@ type Condition struct {
 	// one status to another, if any.
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// Severity of this condition, used to distinguish e.g. warning-level degradation from hard errors.
+	// +optional
+	Severity ConditionSeverity `json:"severity,omitempty"`
+
+	// TransitionCount is the number of times this condition has transitioned from one status to another.
+	// It is maintained by ConditionedStatus.SetConditions and can be used to detect flapping.
+	// +optional
+	TransitionCount int64 `json:"transitionCount,omitempty"`
+
+	// SetBy identifies the controller that set this condition, for debugging resources written by
+	// multiple controllers.
+	// +optional
+	SetBy string `json:"setBy,omitempty"`
 }
 
 // Equal returns true if the condition is identical to the supplied condition,
-// ignoring the LastTransitionTime.
+// ignoring the LastTransitionTime, SetBy, and TransitionCount. Like the LastTransitionTime, the TransitionCount is
+// bookkeeping maintained by SetConditions rather than part of what the condition reports.
 func (c Condition) Equal(other Condition) bool {
 	return c.Type == other.Type &&
 		c.Status == other.Status &&
 		c.Reason == other.Reason &&
 		c.Message == other.Message &&
-		c.ObservedGeneration == other.ObservedGeneration
+		c.ObservedGeneration == other.ObservedGeneration &&
+		c.Severity == other.Severity
+}
+
+// EqualWithSetBy returns true if the condition is Equal to the supplied condition
+// and was set by the same controller.
+func (c Condition) EqualWithSetBy(other Condition) bool {
+	return c.Equal(other) && c.SetBy == other.SetBy
+}
+
+// EqualWithTransitionCount returns true if the condition is Equal to the supplied condition
+// and has transitioned the same number of times.
+func (c Condition) EqualWithTransitionCount(other Condition) bool {
+	return c.Equal(other) && c.TransitionCount == other.TransitionCount
+}
+
+// HasFlappedBeyond returns true if the condition has transitioned more than n times.
+func (c Condition) HasFlappedBeyond(n int64) bool {
+	return c.TransitionCount > n
+}
+
+// IsCosmeticChange returns true if the conditions differ at most in their Message or LastTransitionTime, i.e.
+// updating old to new would not change the condition's meaning. Controllers can skip status writes whose changes
+// are all cosmetic. A change of Severity is not cosmetic.
+func IsCosmeticChange(old, new Condition) bool {
+	return old.Type == new.Type &&
+		old.Status == new.Status &&
+		old.Reason == new.Reason &&
+		old.ObservedGeneration == new.ObservedGeneration &&
+		old.Severity == new.Severity
+}
+
+// Fingerprint returns a stable hash of the fields compared by Equal, so conditions that are Equal have the same
+// fingerprint.
+func (c Condition) Fingerprint() string {
+	h := sha256.New()
+	// quote string fields so that field boundaries are unambiguous
+	fmt.Fprintf(h, "%q %q %d %q %q %q", c.Type, c.Status, c.ObservedGeneration, c.Reason, c.Message, c.Severity)
+	return hex.EncodeToString(h.Sum(nil))
 }
 
 // WithMessage returns a condition by adding the provided message to existing
@@ -111,6 +257,34 @@ func (c Condition) WithMessage(msg string) Condition {
 	return c
 }
 
+// WithReason returns a condition by replacing the reason of existing
+// condition with the provided reason.
+func (c Condition) WithReason(reason ConditionReason) Condition {
+	c.Reason = reason
+	return c
+}
+
+// WithObservedGeneration returns a condition by adding the provided observed
+// generation to existing condition.
+func (c Condition) WithObservedGeneration(gen int64) Condition {
+	c.ObservedGeneration = gen
+	return c
+}
+
+// WithSeverity returns a condition by adding the provided severity to existing
+// condition.
+func (c Condition) WithSeverity(severity ConditionSeverity) Condition {
+	c.Severity = severity
+	return c
+}
+
+// WithSetBy returns a condition by adding the provided controller name to existing
+// condition.
+func (c Condition) WithSetBy(controller string) Condition {
+	c.SetBy = controller
+	return c
+}
+
 // IsEmpty returns true if the condition is empty.
 func (c Condition) IsEmpty() bool {
 	return c.Type == "" &&
@@ -119,6 +293,330 @@ func (c Condition) IsEmpty() bool {
 		c.Message == ""
 }
 
+// String returns a compact, single-line form of the condition for logging, e.g.
+// `Ready=False Reason=Unavailable Msg="api reports unhealthy" (gen 12)`. Empty fields are omitted.
+func (c Condition) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s=%s", c.Type, c.Status)
+	if c.Reason != "" {
+		fmt.Fprintf(&b, " Reason=%s", c.Reason)
+	}
+	if c.Message != "" {
+		fmt.Fprintf(&b, " Msg=%q", c.Message)
+	}
+	if c.ObservedGeneration != 0 {
+		fmt.Fprintf(&b, " (gen %d)", c.ObservedGeneration)
+	}
+	return b.String()
+}
+
+// WorseOf returns the condition with the worse status, where False is worse than Unknown and Unknown is worse
+// than True. Unrecognized statuses are ranked as Unknown. If both conditions have equally bad statuses, a is returned.
+func WorseOf(a, b Condition) Condition {
+	if statusRank(b.Status) < statusRank(a.Status) {
+		return b
+	}
+	return a
+}
+
+// statusRank orders condition statuses from worst to best.
+func statusRank(s corev1.ConditionStatus) int {
+	switch s {
+	case corev1.ConditionFalse:
+		return 0
+	case corev1.ConditionTrue:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// StatusFromMeta converts a metav1.ConditionStatus into a corev1.ConditionStatus.
+// Unrecognized statuses are converted to Unknown.
+func StatusFromMeta(s metav1.ConditionStatus) corev1.ConditionStatus {
+	switch s {
+	case metav1.ConditionTrue:
+		return corev1.ConditionTrue
+	case metav1.ConditionFalse:
+		return corev1.ConditionFalse
+	default:
+		return corev1.ConditionUnknown
+	}
+}
+
+// StatusToMeta converts a corev1.ConditionStatus into a metav1.ConditionStatus.
+// Unrecognized statuses are converted to Unknown.
+func StatusToMeta(s corev1.ConditionStatus) metav1.ConditionStatus {
+	switch s {
+	case corev1.ConditionTrue:
+		return metav1.ConditionTrue
+	case corev1.ConditionFalse:
+		return metav1.ConditionFalse
+	default:
+		return metav1.ConditionUnknown
+	}
+}
+
+// metaV1UnknownReason is the reason used when converting a condition without a reason to a metav1.Condition,
+// which requires a non-empty reason.
+const metaV1UnknownReason = "Unknown"
+
+// ToMetaV1 converts the condition into a metav1.Condition. An empty reason is converted to "Unknown"
+// since metav1.Condition requires a non-empty reason.
+func (c Condition) ToMetaV1() metav1.Condition {
+	reason := string(c.Reason)
+	if reason == "" {
+		reason = metaV1UnknownReason
+	}
+	return metav1.Condition{
+		Type:               string(c.Type),
+		Status:             StatusToMeta(c.Status),
+		ObservedGeneration: c.ObservedGeneration,
+		LastTransitionTime: c.LastTransitionTime,
+		Reason:             reason,
+		Message:            c.Message,
+	}
+}
+
+// FromMetaV1 converts a metav1.Condition into a Condition.
+func FromMetaV1(c metav1.Condition) Condition {
+	return Condition{
+		Type:               ConditionType(c.Type),
+		Status:             StatusFromMeta(c.Status),
+		ObservedGeneration: c.ObservedGeneration,
+		LastTransitionTime: c.LastTransitionTime,
+		Reason:             ConditionReason(c.Reason),
+		Message:            c.Message,
+	}
+}
+
+// ToMetaV1Conditions converts the conditions into metav1.Conditions.
+func ToMetaV1Conditions(conds []Condition) []metav1.Condition {
+	if conds == nil {
+		return nil
+	}
+	out := make([]metav1.Condition, len(conds))
+	for i, c := range conds {
+		out[i] = c.ToMetaV1()
+	}
+	return out
+}
+
+// FromMetaV1Conditions converts metav1.Conditions into Conditions.
+func FromMetaV1Conditions(conds []metav1.Condition) []Condition {
+	if conds == nil {
+		return nil
+	}
+	out := make([]Condition, len(conds))
+	for i, c := range conds {
+		out[i] = FromMetaV1(c)
+	}
+	return out
+}
+
+// A ConditionBuilder builds a Condition through chained setters, e.g.
+//
+//	NewCondition(TypeReady).Status(corev1.ConditionFalse).Reason(ReasonCreating).Message("waiting").Build()
+type ConditionBuilder struct {
+	condition Condition
+}
+
+// NewCondition returns a ConditionBuilder for a condition of the supplied type.
+func NewCondition(ct ConditionType) *ConditionBuilder {
+	return &ConditionBuilder{condition: Condition{Type: ct}}
+}
+
+// Status sets the condition's status.
+func (b *ConditionBuilder) Status(status corev1.ConditionStatus) *ConditionBuilder {
+	b.condition.Status = status
+	return b
+}
+
+// Reason sets the condition's reason.
+func (b *ConditionBuilder) Reason(reason ConditionReason) *ConditionBuilder {
+	b.condition.Reason = reason
+	return b
+}
+
+// Message sets the condition's message.
+func (b *ConditionBuilder) Message(msg string) *ConditionBuilder {
+	b.condition.Message = msg
+	return b
+}
+
+// ObservedGeneration sets the condition's observed generation.
+func (b *ConditionBuilder) ObservedGeneration(gen int64) *ConditionBuilder {
+	b.condition.ObservedGeneration = gen
+	return b
+}
+
+// Build returns the condition with its LastTransitionTime set to now.
+// Build panics if the condition type is empty, since such a condition can never be set on a resource.
+func (b *ConditionBuilder) Build() Condition {
+	if b.condition.Type == "" {
+		panic("api: ConditionBuilder.Build called with an empty condition type")
+	}
+	c := b.condition
+	c.LastTransitionTime = metav1.Now()
+	return c
+}
+
+// severityRank orders condition severities from least to most severe. Conditions without a severity
+// rank below warnings but above informational conditions.
+func severityRank(s ConditionSeverity) int {
+	switch s {
+	case SeverityError:
+		return 3
+	case SeverityWarning:
+		return 2
+	case SeverityInfo:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// DominantFailureReason returns the reason of the most severe False condition, breaking ties by condition type,
+// for use as the reason of an aggregate condition. Returns false if no condition is False.
+func DominantFailureReason(conds []Condition) (ConditionReason, bool) {
+	var dominant *Condition
+	for i, c := range conds {
+		if c.Status != corev1.ConditionFalse {
+			continue
+		}
+		if dominant == nil ||
+			severityRank(c.Severity) > severityRank(dominant.Severity) ||
+			(severityRank(c.Severity) == severityRank(dominant.Severity) && c.Type < dominant.Type) {
+			dominant = &conds[i]
+		}
+	}
+
+	if dominant == nil {
+		return "", false
+	}
+	return dominant.Reason, true
+}
+
+// FindStatusCondition returns a pointer to the condition of the supplied type in conds, or nil if absent.
+// It mirrors meta.FindStatusCondition from k8s.io/apimachinery for Conditions.
+func FindStatusCondition(conds []Condition, ct ConditionType) *Condition {
+	for i := range conds {
+		if conds[i].Type == ct {
+			return &conds[i]
+		}
+	}
+	return nil
+}
+
+// SetStatusCondition sets the condition of the same type in conds to c, or appends c if absent. It mirrors
+// meta.SetStatusCondition from k8s.io/apimachinery for Conditions: the LastTransitionTime is only updated when the
+// status changes and is set to now if unset. conds must be non-nil.
+func SetStatusCondition(conds *[]Condition, c Condition) {
+	if conds == nil {
+		return
+	}
+
+	existing := FindStatusCondition(*conds, c.Type)
+	if existing == nil {
+		if c.LastTransitionTime.IsZero() {
+			c.LastTransitionTime = metav1.Now()
+		}
+		*conds = append(*conds, c)
+		return
+	}
+
+	*existing, _ = updateCondition(*existing, c)
+}
+
+// ConditionProtoStatus is the integer encoding of a condition status in a ConditionProto.
+type ConditionProtoStatus int32
+
+// Condition statuses for ConditionProto.
+const (
+	ConditionProtoStatusUnknown ConditionProtoStatus = 0
+	ConditionProtoStatusTrue    ConditionProtoStatus = 1
+	ConditionProtoStatusFalse   ConditionProtoStatus = 2
+)
+
+// ConditionProto is a plain representation of a Condition without Kubernetes types, suitable for mapping onto
+// protobuf messages, e.g. for gRPC status streaming.
+type ConditionProto struct {
+	Type               string
+	Status             ConditionProtoStatus
+	Reason             string
+	Message            string
+	ObservedGeneration int64
+	// LastTransitionTimeUnix is the last transition time in seconds since the Unix epoch, or 0 if unset.
+	LastTransitionTimeUnix int64
+}
+
+// ToProtoConditions converts the conditions into ConditionProtos.
+// Transition times are truncated to seconds.
+func ToProtoConditions(conds []Condition) []ConditionProto {
+	if conds == nil {
+		return nil
+	}
+	out := make([]ConditionProto, len(conds))
+	for i, c := range conds {
+		p := ConditionProto{
+			Type:               string(c.Type),
+			Status:             ConditionProtoStatusUnknown,
+			Reason:             string(c.Reason),
+			Message:            c.Message,
+			ObservedGeneration: c.ObservedGeneration,
+		}
+		switch c.Status {
+		case corev1.ConditionTrue:
+			p.Status = ConditionProtoStatusTrue
+		case corev1.ConditionFalse:
+			p.Status = ConditionProtoStatusFalse
+		}
+		if !c.LastTransitionTime.IsZero() {
+			p.LastTransitionTimeUnix = c.LastTransitionTime.Unix()
+		}
+		out[i] = p
+	}
+	return out
+}
+
+// FromProtoConditions converts ConditionProtos into Conditions.
+func FromProtoConditions(protos []ConditionProto) []Condition {
+	if protos == nil {
+		return nil
+	}
+	out := make([]Condition, len(protos))
+	for i, p := range protos {
+		c := Condition{
+			Type:               ConditionType(p.Type),
+			Status:             corev1.ConditionUnknown,
+			Reason:             ConditionReason(p.Reason),
+			Message:            p.Message,
+			ObservedGeneration: p.ObservedGeneration,
+		}
+		switch p.Status {
+		case ConditionProtoStatusTrue:
+			c.Status = corev1.ConditionTrue
+		case ConditionProtoStatusFalse:
+			c.Status = corev1.ConditionFalse
+		}
+		if p.LastTransitionTimeUnix != 0 {
+			c.LastTransitionTime = metav1.Unix(p.LastTransitionTimeUnix, 0)
+		}
+		out[i] = c
+	}
+	return out
+}
+
+// StampGeneration returns copies of the supplied conditions with their ObservedGeneration set to gen.
+func StampGeneration(gen int64, conds ...Condition) []Condition {
+	stamped := make([]Condition, len(conds))
+	for i, c := range conds {
+		c.ObservedGeneration = gen
+		stamped[i] = c
+	}
+	return stamped
+}
+
 // A ConditionedStatus reflects the observed status of a resource. Only
 // one condition of each type may exist.
 // +kubebuilder:object:generate=true
@@ -135,6 +633,22 @@ func NewConditionedStatus(c ...Condition) *ConditionedStatus {
 	return s
 }
 
+// AsConditionedStatus returns the ConditionedStatus backing c and true if c implements ConditionedStatusProvider.
+// Otherwise it returns a new ConditionedStatus holding c's conditions and false; changes to it are not
+// reflected in c.
+func AsConditionedStatus(c Conditioned) (*ConditionedStatus, bool) {
+	if p, ok := c.(ConditionedStatusProvider); ok {
+		if s := p.GetConditionedStatus(); s != nil {
+			return s, true
+		}
+	}
+	conditions := c.GetConditions()
+	if conditions != nil {
+		conditions = append([]Condition{}, conditions...)
+	}
+	return &ConditionedStatus{Conditions: conditions}, false
+}
+
 // GetConditions returns the condition for the given ConditionType if exists,
 // otherwise returns nil
 func (s *ConditionedStatus) GetConditions() []Condition {
@@ -153,30 +667,170 @@ func (s *ConditionedStatus) GetCondition(ct ConditionType) Condition {
 	return Condition{Type: ct, Status: corev1.ConditionUnknown}
 }
 
+// IsConditionTrue returns true if the condition of the supplied type is True.
+// A missing condition is treated as Unknown.
+func (s *ConditionedStatus) IsConditionTrue(ct ConditionType) bool {
+	return s.GetCondition(ct).Status == corev1.ConditionTrue
+}
+
+// IsConditionFalse returns true if the condition of the supplied type is False.
+// A missing condition is treated as Unknown.
+func (s *ConditionedStatus) IsConditionFalse(ct ConditionType) bool {
+	return s.GetCondition(ct).Status == corev1.ConditionFalse
+}
+
+// IsConditionUnknown returns true if the condition of the supplied type is Unknown or missing.
+func (s *ConditionedStatus) IsConditionUnknown(ct ConditionType) bool {
+	return s.GetCondition(ct).Status == corev1.ConditionUnknown
+}
+
+// IsReady returns true if the Ready condition is True.
+func (s *ConditionedStatus) IsReady() bool {
+	return s.IsConditionTrue(TypeReady)
+}
+
 // SetConditions sets the supplied conditions, replacing any existing conditions
 // of the same type. This is a no-op if all supplied conditions are identical,
-// ignoring the last transition time, to those already set.
-// TODO(harveyxia) since this is invoked often for the fsm controller frame, improve efficiency by using hash map to make this O(len(c)) instead of O(len(c)*len(s.Conditions))
+// ignoring the last transition time, to those already set. New condition types are
+// appended, preserving the order of existing conditions.
+// When a replaced condition's status is unchanged, its existing LastTransitionTime is preserved.
+// When the status transitions, the supplied LastTransitionTime is used, or the current time if it is unset,
+// and the TransitionCount is incremented.
+// This is invoked often by the FSM controller frame, so it runs in O(len(c)+len(s.Conditions)).
 func (s *ConditionedStatus) SetConditions(c ...Condition) {
+	// index maps each type to the indices of all existing conditions of that type, so that duplicates are
+	// replaced as well
+	index := make(map[ConditionType][]int, len(s.Conditions)+len(c))
+	for i, existing := range s.Conditions {
+		index[existing.Type] = append(index[existing.Type], i)
+	}
+
 	for _, new := range c {
-		exists := false
-		for i, existing := range s.Conditions {
-			if existing.Type != new.Type {
-				continue
-			}
+		indices, exists := index[new.Type]
+		if !exists {
+			index[new.Type] = []int{len(s.Conditions)}
+			s.Conditions = append(s.Conditions, new)
+			continue
+		}
 
-			if existing.Equal(new) {
-				exists = true
-				continue
+		for _, i := range indices {
+			if updated, changed := updateCondition(s.Conditions[i], new); changed {
+				s.Conditions[i] = updated
 			}
+		}
+	}
+}
+
+// SetConditionsWithGeneration sets the supplied conditions like SetConditions after stamping each with the supplied
+// observed generation, overwriting any generation already set on them.
+func (s *ConditionedStatus) SetConditionsWithGeneration(gen int64, c ...Condition) {
+	s.SetConditions(StampGeneration(gen, c...)...)
+}
 
-			s.Conditions[i] = new
-			exists = true
+// SetConditionsSorted sets the supplied conditions like SetConditions, then sorts all conditions by type
+// so that the serialized order is deterministic across reconciles.
+func (s *ConditionedStatus) SetConditionsSorted(c ...Condition) {
+	s.SetConditions(c...)
+	s.SortByType()
+}
+
+// updateCondition returns the result of replacing existing with new, carrying over the
+// LastTransitionTime if the status is unchanged and maintaining the TransitionCount.
+// Returns false if the replacement would be a no-op.
+func updateCondition(existing, new Condition) (Condition, bool) {
+	if existing.Status == new.Status {
+		new.LastTransitionTime = existing.LastTransitionTime
+		new.TransitionCount = existing.TransitionCount
+	} else {
+		if new.LastTransitionTime.IsZero() {
+			new.LastTransitionTime = metav1.Now()
 		}
-		if !exists {
-			s.Conditions = append(s.Conditions, new)
+		new.TransitionCount = existing.TransitionCount + 1
+	}
+
+	if existing.Equal(new) {
+		return existing, false
+	}
+	return new, true
+}
+
+// AnnotateCondition updates the reason and message of the existing condition of the supplied type, preserving its
+// status and last transition time. Returns false if no condition of the supplied type exists.
+func (s *ConditionedStatus) AnnotateCondition(ct ConditionType, reason ConditionReason, msg string) bool {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == ct {
+			s.Conditions[i].Reason = reason
+			s.Conditions[i].Message = msg
+			return true
+		}
+	}
+	return false
+}
+
+// SortByType sorts the conditions by type. The sort is stable, so conditions of the same type keep their
+// relative order. Controllers can call this before writing status to avoid diff churn from reordering.
+func (s *ConditionedStatus) SortByType() {
+	sort.SliceStable(s.Conditions, func(i, j int) bool { return s.Conditions[i].Type < s.Conditions[j].Type })
+}
+
+// SortedConditions returns a copy of the conditions sorted by type, leaving the conditions of s untouched.
+func (s *ConditionedStatus) SortedConditions() []Condition {
+	sorted := make([]Condition, len(s.Conditions))
+	copy(sorted, s.Conditions)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Type < sorted[j].Type })
+	return sorted
+}
+
+// ETag returns a stable hash of the conditions, suitable as an HTTP entity tag once quoted. It hashes the
+// Condition.Fingerprint of each condition regardless of order, so like Equal it only changes when a condition
+// meaningfully changes.
+func (s *ConditionedStatus) ETag() string {
+	h := sha256.New()
+	for _, c := range s.SortedConditions() {
+		fmt.Fprintln(h, c.Fingerprint())
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// IsSorted returns true if the conditions are sorted by type.
+func (s *ConditionedStatus) IsSorted() bool {
+	return sort.SliceIsSorted(s.Conditions, func(i, j int) bool { return s.Conditions[i].Type < s.Conditions[j].Type })
+}
+
+// TruncateTransitionTimes truncates each condition's LastTransitionTime to whole seconds, the precision with which
+// it is serialized, so that in-memory conditions compare equal to their persisted form.
+func (s *ConditionedStatus) TruncateTransitionTimes() {
+	for i := range s.Conditions {
+		s.Conditions[i].LastTransitionTime = s.Conditions[i].LastTransitionTime.Rfc3339Copy()
+	}
+}
+
+// Normalize puts the status into its canonical form by truncating transition times to their serialized precision
+// and sorting conditions by type.
+func (s *ConditionedStatus) Normalize() {
+	s.TruncateTransitionTimes()
+	s.SortByType()
+}
+
+// RemoveConditions removes all conditions of the supplied types, preserving the order of the remaining conditions.
+// Types that aren't present are ignored.
+func (s *ConditionedStatus) RemoveConditions(conditionTypes ...ConditionType) {
+	if len(s.Conditions) == 0 || len(conditionTypes) == 0 {
+		return
+	}
+
+	remove := make(map[ConditionType]struct{}, len(conditionTypes))
+	for _, t := range conditionTypes {
+		remove[t] = struct{}{}
+	}
+
+	conditions := s.Conditions[:0]
+	for _, c := range s.Conditions {
+		if _, ok := remove[c.Type]; !ok {
+			conditions = append(conditions, c)
 		}
 	}
+	s.Conditions = conditions
 }
 
 // Equal returns true if the status is identical to the supplied status,
@@ -209,60 +863,837 @@ func (s *ConditionedStatus) Equal(other *ConditionedStatus) bool {
 	return true
 }
 
-// Creating returns a condition indicating the resource is currently
-// being created.
-func Creating() Condition {
-	return Condition{
-		Type:               TypeReady,
-		Status:             corev1.ConditionFalse,
-		LastTransitionTime: metav1.Now(),
-		Reason:             ReasonCreating,
+// ConditionsString returns the conditions in order, each formatted by Condition.String and separated by ", ".
+// It is deliberately not named String, which would be promoted onto status types embedding ConditionedStatus and
+// hide their other fields when formatted.
+func (s *ConditionedStatus) ConditionsString() string {
+	if s == nil {
+		return ""
+	}
+	strs := make([]string, len(s.Conditions))
+	for i, c := range s.Conditions {
+		strs[i] = c.String()
 	}
+	return strings.Join(strs, ", ")
 }
 
-// Deleting returns a condition indicating the resource is currently
-// being deleted.
-func Deleting() Condition {
-	return Condition{
-		Type:               TypeReady,
-		Status:             corev1.ConditionFalse,
-		LastTransitionTime: metav1.Now(),
-		Reason:             ReasonDeleting,
+// Filter returns the conditions for which pred returns true, in order.
+func (s *ConditionedStatus) Filter(pred func(Condition) bool) []Condition {
+	var matched []Condition
+	for _, c := range s.Conditions {
+		if pred(c) {
+			matched = append(matched, c)
+		}
 	}
+	return matched
 }
 
-// Available returns a condition indicating the resource is
-// currently observed to be available for use.
-func Available() Condition {
-	return Condition{
-		Type:               TypeReady,
-		Status:             corev1.ConditionTrue,
-		LastTransitionTime: metav1.Now(),
-		Reason:             ReasonAvailable,
+// typesOf returns the types of the supplied conditions, in order.
+func typesOf(conds []Condition) []ConditionType {
+	var conditionTypes []ConditionType
+	for _, c := range conds {
+		conditionTypes = append(conditionTypes, c.Type)
 	}
+	return conditionTypes
 }
 
-// Unavailable returns a condition indicating the resource is not
-// currently available for use. Unavailable should be set only when Crossplane
-// expects the resource to be available but knows it is not, for example
-// because its API reports it is unhealthy.
-func Unavailable() Condition {
-	return Condition{
-		Type:               TypeReady,
-		Status:             corev1.ConditionFalse,
-		LastTransitionTime: metav1.Now(),
-		Reason:             ReasonUnavailable,
-	}
+// IsConditionStale returns true if the condition was observed for a generation older than the current generation,
+// i.e. the controller has not yet reconciled the latest spec.
+func IsConditionStale(c Condition, currentGeneration int64) bool {
+	return c.ObservedGeneration < currentGeneration
 }
 
-// ReconcileSuccess returns a condition indicating that Crossplane successfully
-// completed the most recent reconciliation of the resource.
-func ReconcileSuccess() Condition {
-	return Condition{
-		Type:               TypeSynced,
-		Status:             corev1.ConditionTrue,
-		LastTransitionTime: metav1.Now(),
-		Reason:             ReasonReconcileSuccess,
+// StaleConditions returns the conditions that are stale with respect to the current generation.
+func (s *ConditionedStatus) StaleConditions(currentGeneration int64) []Condition {
+	return s.Filter(func(c Condition) bool { return IsConditionStale(c, currentGeneration) })
+}
+
+// StaleConditionsOf returns the conditions of the resource that are stale with respect to its own generation.
+func StaleConditionsOf(c Conditioned) []Condition {
+	s := &ConditionedStatus{Conditions: c.GetConditions()}
+	return s.StaleConditions(c.GetGeneration())
+}
+
+// MissingTypes returns the expected condition types that are absent, in the order supplied. FSM controllers use
+// this to detect states that have not yet been reconciled.
+func (s *ConditionedStatus) MissingTypes(expected ...ConditionType) []ConditionType {
+	var missing []ConditionType
+	for _, ct := range expected {
+		if FindStatusCondition(s.Conditions, ct) == nil {
+			missing = append(missing, ct)
+		}
+	}
+	return missing
+}
+
+// IsTerminallyFailed returns true if any condition is False with one of the supplied terminal reasons and the
+// resource is not progressing, i.e. the Ready condition is not Creating or Deleting. If no terminal reasons are
+// supplied, DefaultTerminalReasons are used. Controllers use this to stop requeuing resources that cannot succeed
+// without a change.
+func (s *ConditionedStatus) IsTerminallyFailed(terminal ...ConditionReason) bool {
+	if reason := s.GetCondition(TypeReady).Reason; reason == ReasonCreating || reason == ReasonDeleting {
+		return false
+	}
+	if len(terminal) == 0 {
+		terminal = DefaultTerminalReasons()
+	}
+	for _, c := range s.Conditions {
+		if c.Status != corev1.ConditionFalse {
+			continue
+		}
+		for _, r := range terminal {
+			if c.Reason == r {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Validate returns an error listing all problems with the conditions: empty types, statuses other than True,
+// False, or Unknown, and duplicate types.
+func (s *ConditionedStatus) Validate() error {
+	var errs []error
+	seen := map[ConditionType]struct{}{}
+	for i, c := range s.Conditions {
+		if c.Type == "" {
+			errs = append(errs, fmt.Errorf("condition %d has an empty type", i))
+		}
+		switch c.Status {
+		case corev1.ConditionTrue, corev1.ConditionFalse, corev1.ConditionUnknown:
+		default:
+			errs = append(errs, fmt.Errorf("condition %q has invalid status %q", c.Type, c.Status))
+		}
+		if _, ok := seen[c.Type]; ok {
+			errs = append(errs, fmt.Errorf("duplicate condition type %q", c.Type))
+		}
+		seen[c.Type] = struct{}{}
+	}
+	return errors.Join(errs...)
+}
+
+// PascalCaseConditionType matches PascalCase condition types such as "Ready" and "ManagedResourcesHealthy",
+// following the Kubernetes API conventions for condition types.
+var PascalCaseConditionType = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+
+// ValidateConditionTypeNaming returns an error listing each condition whose type does not match pattern.
+// If pattern is nil, PascalCaseConditionType is used.
+func ValidateConditionTypeNaming(conds []Condition, pattern *regexp.Regexp) error {
+	if pattern == nil {
+		pattern = PascalCaseConditionType
+	}
+	var errs []error
+	for _, c := range conds {
+		if !pattern.MatchString(string(c.Type)) {
+			errs = append(errs, fmt.Errorf("condition type %q does not match %s", c.Type, pattern))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ParseConditionedStatus unmarshals a ConditionedStatus from JSON and validates it.
+func ParseConditionedStatus(data []byte) (*ConditionedStatus, error) {
+	s := &ConditionedStatus{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("unmarshalling conditioned status: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("validating conditioned status: %w", err)
+	}
+	return s, nil
+}
+
+// Headline returns the message of the condition with the worst status, ignoring conditions without a message,
+// for use as a single summary line. Ties are broken by condition order. Returns an empty string if no condition
+// has a message.
+func (s *ConditionedStatus) Headline() string {
+	var headline *Condition
+	for i, c := range s.Conditions {
+		if c.Message == "" {
+			continue
+		}
+		if headline == nil || statusRank(c.Status) < statusRank(headline.Status) {
+			headline = &s.Conditions[i]
+		}
+	}
+
+	if headline == nil {
+		return ""
+	}
+	return headline.Message
+}
+
+// HasFutureGenerations returns the types of conditions whose ObservedGeneration is greater than the supplied
+// current generation, which indicates that the conditions were written by a buggy writer or restored from a backup.
+func (s *ConditionedStatus) HasFutureGenerations(currentGen int64) []ConditionType {
+	return typesOf(s.Filter(func(c Condition) bool { return c.ObservedGeneration > currentGen }))
+}
+
+// NeverTransitioned returns the types of conditions with a zero LastTransitionTime, which usually indicates
+// that the condition was constructed without using one of the condition constructors.
+func (s *ConditionedStatus) NeverTransitioned() []ConditionType {
+	return typesOf(s.Filter(func(c Condition) bool { return c.LastTransitionTime.IsZero() }))
+}
+
+// ConditionsMissingGeneration returns the types of conditions with a zero ObservedGeneration,
+// which usually indicates that the writer forgot to set it.
+func (s *ConditionedStatus) ConditionsMissingGeneration() []ConditionType {
+	return typesOf(s.Filter(func(c Condition) bool { return c.ObservedGeneration == 0 }))
+}
+
+// StampObservedGeneration sets the ObservedGeneration of all conditions to the supplied generation.
+func (s *ConditionedStatus) StampObservedGeneration(gen int64) {
+	for i := range s.Conditions {
+		s.Conditions[i].ObservedGeneration = gen
+	}
+}
+
+// EventMessageLimit is the maximum length in bytes of a Kubernetes event message.
+const EventMessageLimit = 1024
+
+// EventMessage returns a single-line summary of the conditions suitable for a Kubernetes event message,
+// e.g. "Ready=False (Unavailable): api reports unhealthy; Synced=True". The reason and message are only included for
+// conditions that are not True. The result is truncated to EventMessageLimit bytes.
+func (s *ConditionedStatus) EventMessage() string {
+	parts := make([]string, 0, len(s.Conditions))
+	for _, c := range s.Conditions {
+		part := fmt.Sprintf("%s=%s", c.Type, c.Status)
+		if c.Status != corev1.ConditionTrue {
+			if c.Reason != "" {
+				part += fmt.Sprintf(" (%s)", c.Reason)
+			}
+			if c.Message != "" {
+				part += ": " + c.Message
+			}
+		}
+		parts = append(parts, part)
+	}
+
+	return truncate(strings.Join(parts, "; "), EventMessageLimit)
+}
+
+// truncate shortens s to at most limit bytes, marking truncation with a trailing ellipsis
+// and never splitting a multi-byte character.
+func truncate(s string, limit int) string {
+	const ellipsis = "..."
+	if len(s) <= limit {
+		return s
+	}
+	if limit <= len(ellipsis) {
+		return ellipsis[:limit]
+	}
+
+	end := limit - len(ellipsis)
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end] + ellipsis
+}
+
+// OversizedMessages returns the types of conditions whose message is longer than limit bytes.
+func (s *ConditionedStatus) OversizedMessages(limit int) []ConditionType {
+	return typesOf(s.Filter(func(c Condition) bool { return len(c.Message) > limit }))
+}
+
+// Report returns a multi-line, column-aligned table of the conditions with the columns
+// TYPE, STATUS, REASON, AGE, and MESSAGE, for describe-style CLI output.
+func (s *ConditionedStatus) Report() string {
+	return s.ReportAt(time.Now())
+}
+
+// ReportAt is like Report but computes the age of each condition relative to now,
+// which keeps the output stable for golden tests.
+func (s *ConditionedStatus) ReportAt(now time.Time) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tSTATUS\tREASON\tAGE\tMESSAGE")
+	for _, c := range s.Conditions {
+		age := "<unknown>"
+		if !c.LastTransitionTime.IsZero() {
+			age = duration.HumanDuration(now.Sub(c.LastTransitionTime.Time))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, age, c.Message)
+	}
+	// writes to a strings.Builder cannot fail
+	_ = w.Flush()
+	return b.String()
+}
+
+// HealthResponse is a JSON-serializable summary of a ConditionedStatus for exposing resource health over HTTP.
+type HealthResponse struct {
+	// Status is the status of the Ready condition, i.e. "True", "False", or "Unknown".
+	Status string `json:"status"`
+
+	// Conditions summarizes each condition of the resource.
+	Conditions []ConditionSummary `json:"conditions,omitempty"`
+}
+
+// ConditionSummary is a JSON-serializable summary of a single Condition.
+type ConditionSummary struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// HealthResponse returns a HealthResponse summarizing the status.
+func (s *ConditionedStatus) HealthResponse() HealthResponse {
+	resp := HealthResponse{
+		Status: string(s.GetCondition(TypeReady).Status),
+	}
+	for _, c := range s.Conditions {
+		resp.Conditions = append(resp.Conditions, ConditionSummary{
+			Type:    string(c.Type),
+			Status:  string(c.Status),
+			Reason:  string(c.Reason),
+			Message: c.Message,
+		})
+	}
+	return resp
+}
+
+const (
+	annotationStatusSuffix = ".status"
+	annotationReasonSuffix = ".reason"
+)
+
+// ToAnnotationMap mirrors the type, status, and reason of each condition into a flat map suitable for annotations,
+// using the keys "<prefix><type>.status" and "<prefix><type>.reason". Messages are omitted.
+func (s *ConditionedStatus) ToAnnotationMap(prefix string) map[string]string {
+	m := make(map[string]string, 2*len(s.Conditions))
+	for _, c := range s.Conditions {
+		m[prefix+c.Type.String()+annotationStatusSuffix] = string(c.Status)
+		m[prefix+c.Type.String()+annotationReasonSuffix] = string(c.Reason)
+	}
+	return m
+}
+
+// ConditionedStatusFromAnnotationMap is the inverse of ConditionedStatus.ToAnnotationMap. Keys without the prefix
+// are ignored. Conditions are sorted by type, and their messages and transition times are left empty.
+func ConditionedStatusFromAnnotationMap(prefix string, m map[string]string) *ConditionedStatus {
+	byType := map[ConditionType]*Condition{}
+	condition := func(ct ConditionType) *Condition {
+		if _, ok := byType[ct]; !ok {
+			byType[ct] = &Condition{Type: ct}
+		}
+		return byType[ct]
+	}
+
+	for k, v := range m {
+		name, ok := strings.CutPrefix(k, prefix)
+		if !ok {
+			continue
+		}
+		if ct, ok := strings.CutSuffix(name, annotationStatusSuffix); ok {
+			condition(ConditionType(ct)).Status = corev1.ConditionStatus(v)
+		} else if ct, ok := strings.CutSuffix(name, annotationReasonSuffix); ok {
+			condition(ConditionType(ct)).Reason = ConditionReason(v)
+		}
+	}
+
+	s := &ConditionedStatus{}
+	for _, c := range byType {
+		s.Conditions = append(s.Conditions, *c)
+	}
+	s.SortByType()
+	return s
+}
+
+// ConditionDelta returns the changes required to transform the actual status into the desired status.
+// toSet contains the desired conditions that are absent from or differ from the actual status, ignoring
+// LastTransitionTime. toRemove contains the types of actual conditions that are absent from the desired status.
+// Either argument may be nil, which is treated as a status with no conditions.
+func ConditionDelta(actual, desired *ConditionedStatus) (toSet []Condition, toRemove []ConditionType) {
+	actualByType := map[ConditionType]Condition{}
+	if actual != nil {
+		for _, c := range actual.Conditions {
+			actualByType[c.Type] = c
+		}
+	}
+
+	desiredTypes := map[ConditionType]struct{}{}
+	if desired != nil {
+		for _, c := range desired.Conditions {
+			desiredTypes[c.Type] = struct{}{}
+			if existing, ok := actualByType[c.Type]; ok && existing.Equal(c) {
+				continue
+			}
+			toSet = append(toSet, c)
+		}
+	}
+
+	if actual != nil {
+		for _, c := range actual.Conditions {
+			if _, ok := desiredTypes[c.Type]; !ok {
+				toRemove = append(toRemove, c.Type)
+			}
+		}
+	}
+
+	return toSet, toRemove
+}
+
+// SyncStale returns true if the Synced condition is absent, is not True, or was observed for a generation older
+// than the supplied generation. Controllers use this to decide whether spec changes still need to be reconciled.
+// A nil status is always stale.
+func SyncStale(s *ConditionedStatus, generation int64) bool {
+	if s == nil {
+		return true
+	}
+	synced := s.GetCondition(TypeSynced)
+	return synced.Status != corev1.ConditionTrue || synced.ObservedGeneration < generation
+}
+
+// SummarizeReady returns a Ready condition aggregated from the conditions of the supplied types. It is Available if
+// all of them are True, otherwise it is Unavailable with a message listing the types that are not True. Absent
+// conditions are treated as Unknown. If no types are supplied, all conditions other than Ready are aggregated.
+// If there is nothing to aggregate, e.g. for a nil status or a newly created resource without conditions, the
+// Ready condition is Unknown with reason NothingToAggregate.
+func SummarizeReady(s *ConditionedStatus, conditionTypes ...ConditionType) Condition {
+	if s == nil {
+		s = &ConditionedStatus{}
+	}
+	if len(conditionTypes) == 0 {
+		for _, c := range s.Conditions {
+			if c.Type != TypeReady {
+				conditionTypes = append(conditionTypes, c.Type)
+			}
+		}
+	}
+	if len(conditionTypes) == 0 {
+		return nothingToAggregate("No conditions to aggregate.")
+	}
+
+	var notReady []string
+	for _, t := range conditionTypes {
+		if s.GetCondition(t).Status != corev1.ConditionTrue {
+			notReady = append(notReady, t.String())
+		}
+	}
+
+	if len(notReady) == 0 {
+		return Available()
+	}
+	return Unavailable().WithMessage(fmt.Sprintf("Conditions are not True: %s", strings.Join(notReady, ", ")))
+}
+
+// nothingToAggregate returns the Ready condition reported when an aggregation has no inputs.
+func nothingToAggregate(msg string) Condition {
+	return Condition{
+		Type:               TypeReady,
+		Status:             corev1.ConditionUnknown,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNothingToAggregate,
+		Message:            msg,
+		Severity:           SeverityInfo,
+	}
+}
+
+// Rollup returns a condition of the target type aggregated from the source conditions. It is True if all present
+// source conditions are True, False if any is False, with a message listing the False types, and Unknown otherwise.
+// Absent source conditions are skipped, and the result is Unknown if none are present.
+func (s *ConditionedStatus) Rollup(target ConditionType, sources ...ConditionType) Condition {
+	var present, falseTypes, unknownTypes []string
+	for _, ct := range sources {
+		c := FindStatusCondition(s.Conditions, ct)
+		if c == nil {
+			continue
+		}
+		present = append(present, ct.String())
+		switch c.Status {
+		case corev1.ConditionTrue:
+		case corev1.ConditionFalse:
+			falseTypes = append(falseTypes, ct.String())
+		default:
+			unknownTypes = append(unknownTypes, ct.String())
+		}
+	}
+
+	c := Condition{
+		Type:               target,
+		LastTransitionTime: metav1.Now(),
+	}
+	switch {
+	case len(present) == 0:
+		c.Status = corev1.ConditionUnknown
+		c.Reason = ReasonRollupIncomplete
+		c.Message = "No source conditions are present."
+	case len(falseTypes) > 0:
+		c.Status = corev1.ConditionFalse
+		c.Reason = ReasonRollupIncomplete
+		c.Message = fmt.Sprintf("Conditions are False: %s", strings.Join(falseTypes, ", "))
+	case len(unknownTypes) > 0:
+		c.Status = corev1.ConditionUnknown
+		c.Reason = ReasonRollupIncomplete
+		c.Message = fmt.Sprintf("Conditions are Unknown: %s", strings.Join(unknownTypes, ", "))
+	default:
+		c.Status = corev1.ConditionTrue
+		c.Reason = ReasonRollupComplete
+	}
+	return c
+}
+
+// SameAggregateReady returns true if SummarizeReady produces the same status and reason for both statuses,
+// even if the underlying conditions differ. Controllers can use this to skip redundant status writes.
+func SameAggregateReady(a, b *ConditionedStatus, conditionTypes ...ConditionType) bool {
+	ra := SummarizeReady(a, conditionTypes...)
+	rb := SummarizeReady(b, conditionTypes...)
+	return ra.Status == rb.Status && ra.Reason == rb.Reason
+}
+
+// MarkDeleting sets the Ready condition to Deleting and removes all conditions other than Ready, Synced,
+// and the supplied types to keep. Controllers call this when finalizing a resource so that progress
+// conditions from the resource's lifecycle don't linger during deletion.
+func MarkDeleting(s *ConditionedStatus, keep ...ConditionType) {
+	retained := map[ConditionType]struct{}{
+		TypeReady:  {},
+		TypeSynced: {},
+	}
+	for _, t := range keep {
+		retained[t] = struct{}{}
+	}
+
+	conditions := s.Conditions[:0]
+	for _, c := range s.Conditions {
+		if _, ok := retained[c.Type]; ok {
+			conditions = append(conditions, c)
+		}
+	}
+	s.Conditions = conditions
+
+	s.SetConditions(Deleting())
+}
+
+// ConditionsMergePatch returns a JSON merge patch (RFC 7386) that sets only `status.conditions` to the supplied
+// conditions, for use with the status subresource to avoid conflicts with other status writers. JSON merge patches
+// replace arrays wholesale, so the supplied conditions must be the complete desired set; any existing condition
+// not included is removed, and nil conditions are patched as null, which removes `status.conditions` entirely.
+// Strategic merge patches are not used because they are unsupported for custom resources.
+func ConditionsMergePatch(conds []Condition) client.Patch {
+	patch := map[string]any{
+		"status": map[string]any{
+			"conditions": conds,
+		},
+	}
+	// marshalling cannot fail since Condition only contains JSON-safe fields
+	data, _ := json.Marshal(patch)
+	return client.RawPatch(types.MergePatchType, data)
+}
+
+// MergeWorst merges the supplied statuses, keeping the worse condition of each type as determined by WorseOf.
+// Conditions are ordered by the first occurrence of their type. Nil statuses are skipped.
+func MergeWorst(statuses ...*ConditionedStatus) *ConditionedStatus {
+	merged := &ConditionedStatus{}
+	index := map[ConditionType]int{}
+	for _, s := range statuses {
+		if s == nil {
+			continue
+		}
+		for _, c := range s.Conditions {
+			if i, ok := index[c.Type]; ok {
+				merged.Conditions[i] = WorseOf(merged.Conditions[i], c)
+				continue
+			}
+			index[c.Type] = len(merged.Conditions)
+			merged.Conditions = append(merged.Conditions, c)
+		}
+	}
+	return merged
+}
+
+// RegressedTypes returns the types of conditions that were True in old but are False or Unknown in new, including
+// conditions that were removed. All condition types defined by this package are positive polarity, i.e. True is the
+// healthy state.
+func RegressedTypes(old, new *ConditionedStatus) []ConditionType {
+	if old == nil {
+		return nil
+	}
+	if new == nil {
+		new = &ConditionedStatus{}
+	}
+
+	var regressed []ConditionType
+	for _, c := range old.Conditions {
+		if c.Status == corev1.ConditionTrue && new.GetCondition(c.Type).Status != corev1.ConditionTrue {
+			regressed = append(regressed, c.Type)
+		}
+	}
+	return regressed
+}
+
+// ManagedResourceStatus reports the readiness of a single managed resource.
+type ManagedResourceStatus struct {
+	// Ref references the managed resource.
+	Ref TypedObjectRef
+
+	// Ready is true if the managed resource is ready.
+	Ready bool
+}
+
+// AggregateManagedReady returns a Ready condition that is Available if all managed resources are ready,
+// otherwise Unavailable with a message listing the managed resources that are not ready. If there are no managed
+// resources, the Ready condition is Unknown with reason NothingToAggregate, as for SummarizeReady.
+func AggregateManagedReady(managed []ManagedResourceStatus) Condition {
+	if len(managed) == 0 {
+		return nothingToAggregate("No managed resources to aggregate.")
+	}
+
+	var notReady []string
+	for _, m := range managed {
+		if !m.Ready {
+			notReady = append(notReady, m.Ref.String())
+		}
+	}
+
+	if len(notReady) == 0 {
+		return Available()
+	}
+	return Unavailable().WithMessage(fmt.Sprintf("Managed resources are not ready: %s", strings.Join(notReady, ", ")))
+}
+
+// SetReadyFromManaged sets the Synced condition from the supplied sync error and the Ready condition from the
+// readiness of the managed resources.
+func SetReadyFromManaged(s *ConditionedStatus, managed []ManagedResourceStatus, syncErr error) {
+	synced := ReconcileSuccess()
+	if syncErr != nil {
+		synced = ReconcileError(syncErr)
+	}
+	s.SetConditions(synced, AggregateManagedReady(managed))
+}
+
+// ReconcileSummary summarizes the outcome of a reconcile for logging.
+type ReconcileSummary struct {
+	// Ready is the status of the Ready condition.
+	Ready corev1.ConditionStatus
+
+	// Synced is the status of the Synced condition.
+	Synced corev1.ConditionStatus
+
+	// ManagedCount is the number of managed resources.
+	ManagedCount int
+
+	// ChangedConditions are the types of conditions that were added, updated, or removed since the previous status.
+	ChangedConditions []ConditionType
+}
+
+// BuildReconcileSummary returns a ReconcileSummary for the current status s, compared against the status prior
+// to the reconcile, prev, which may be nil.
+func BuildReconcileSummary(s *ConditionedStatus, managed []TypedObjectRef, prev *ConditionedStatus) ReconcileSummary {
+	summary := ReconcileSummary{
+		Ready:        s.GetCondition(TypeReady).Status,
+		Synced:       s.GetCondition(TypeSynced).Status,
+		ManagedCount: len(managed),
+	}
+
+	toSet, toRemove := ConditionDelta(prev, s)
+	for _, c := range toSet {
+		summary.ChangedConditions = append(summary.ChangedConditions, c.Type)
+	}
+	summary.ChangedConditions = append(summary.ChangedConditions, toRemove...)
+
+	return summary
+}
+
+// ConditionTransition describes a change in the status of a condition.
+type ConditionTransition struct {
+	Type ConditionType
+
+	// OldStatus is the previous status, or empty if the condition was added.
+	OldStatus corev1.ConditionStatus
+
+	// NewStatus is the current status, or empty if the condition was removed.
+	NewStatus corev1.ConditionStatus
+
+	// Reason and Message are those of the current condition, or of the previous condition if it was removed.
+	Reason  ConditionReason
+	Message string
+}
+
+// DiffConditionsForEvents returns a transition for each condition whose status differs between old and new,
+// including added and removed conditions. Transitions are ordered by new, followed by removed conditions in the
+// order of old. Consumers typically map each transition to a Kubernetes event, e.g. Normal for True and Warning
+// for False.
+func DiffConditionsForEvents(old, new []Condition) []ConditionTransition {
+	var transitions []ConditionTransition
+	for _, n := range new {
+		o := FindStatusCondition(old, n.Type)
+		if o != nil && o.Status == n.Status {
+			continue
+		}
+		t := ConditionTransition{
+			Type:      n.Type,
+			NewStatus: n.Status,
+			Reason:    n.Reason,
+			Message:   n.Message,
+		}
+		if o != nil {
+			t.OldStatus = o.Status
+		}
+		transitions = append(transitions, t)
+	}
+
+	for _, o := range old {
+		if FindStatusCondition(new, o.Type) == nil {
+			transitions = append(transitions, ConditionTransition{
+				Type:      o.Type,
+				OldStatus: o.Status,
+				Reason:    o.Reason,
+				Message:   o.Message,
+			})
+		}
+	}
+	return transitions
+}
+
+// messageSeparator joins the distinct messages of merged conditions.
+const messageSeparator = "; "
+
+// MergeConditions merges the incoming conditions into the existing conditions by type. When a type's statuses
+// differ, the incoming condition takes precedence. When they match, the incoming condition is kept with the distinct,
+// non-empty messages of both joined by "; ", and the existing LastTransitionTime is preserved. This lets several
+// sub-reconcilers contribute to the message of a shared condition. The result is sorted by type.
+func MergeConditions(existing, incoming []Condition) []Condition {
+	merged := map[ConditionType]Condition{}
+	for _, c := range append(append([]Condition{}, existing...), incoming...) {
+		prev, ok := merged[c.Type]
+		if !ok {
+			merged[c.Type] = c
+			continue
+		}
+		if prev.Status == c.Status {
+			c.Message = joinDistinctMessages(prev.Message, c.Message)
+			c.LastTransitionTime = prev.LastTransitionTime
+		}
+		merged[c.Type] = c
+	}
+
+	result := make([]Condition, 0, len(merged))
+	for _, c := range merged {
+		result = append(result, c)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Type < result[j].Type })
+	return result
+}
+
+// joinDistinctMessages joins the distinct, non-empty messages, including those previously joined by
+// messageSeparator, preserving first-seen order.
+func joinDistinctMessages(msgs ...string) string {
+	var distinct []string
+	seen := map[string]struct{}{}
+	for _, msg := range msgs {
+		for _, part := range strings.Split(msg, messageSeparator) {
+			if part == "" {
+				continue
+			}
+			if _, ok := seen[part]; ok {
+				continue
+			}
+			seen[part] = struct{}{}
+			distinct = append(distinct, part)
+		}
+	}
+	return strings.Join(distinct, messageSeparator)
+}
+
+// Creating returns a condition indicating the resource is currently
+// being created.
+func Creating() Condition {
+	return Condition{
+		Type:               TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCreating,
+		Severity:           SeverityInfo,
+	}
+}
+
+// Deleting returns a condition indicating the resource is currently
+// being deleted.
+func Deleting() Condition {
+	return Condition{
+		Type:               TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeleting,
+	}
+}
+
+// Available returns a condition indicating the resource is
+// currently observed to be available for use.
+func Available() Condition {
+	return Condition{
+		Type:               TypeReady,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAvailable,
+	}
+}
+
+// Unavailable returns a condition indicating the resource is not
+// currently available for use. Unavailable should be set only when Crossplane
+// expects the resource to be available but knows it is not, for example
+// because its API reports it is unhealthy.
+func Unavailable() Condition {
+	return Condition{
+		Type:               TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnavailable,
+		Severity:           SeverityError,
+	}
+}
+
+// QuotaExceeded returns a condition indicating the resource is not available
+// because a quota or limit on the named resource has been exhausted, for example
+// when creating a child resource is rejected by a ResourceQuota.
+func QuotaExceeded(resource string, msg string) Condition {
+	message := fmt.Sprintf("Quota exceeded for %s", resource)
+	if msg != "" {
+		message += ": " + msg
+	}
+	return Condition{
+		Type:               TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonQuotaExceeded,
+		Message:            message,
+		Severity:           SeverityError,
+	}
+}
+
+// AdmissionDenied returns a condition indicating the resource is not available
+// because the API server, typically via an admission webhook, rejected a child
+// resource. msg should describe why the request was denied.
+func AdmissionDenied(msg string) Condition {
+	return Condition{
+		Type:               TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAdmissionDenied,
+		Message:            msg,
+		Severity:           SeverityError,
+	}
+}
+
+// ReconcileSuccess returns a condition indicating that Crossplane successfully
+// completed the most recent reconciliation of the resource.
+func ReconcileSuccess() Condition {
+	return Condition{
+		Type:               TypeSynced,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReconcileSuccess,
+	}
+}
+
+// ReconcilePending returns a condition indicating that the resource has not
+// yet been reconciled.
+func ReconcilePending() Condition {
+	return Condition{
+		Type:               TypeSynced,
+		Status:             corev1.ConditionUnknown,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReconcilePending,
 	}
 }
 
@@ -277,7 +1708,84 @@ func ReconcileError(err error) Condition {
 		LastTransitionTime: metav1.Now(),
 		Reason:             ReasonReconcileError,
 		Message:            err.Error(),
+		Severity:           SeverityError,
+	}
+}
+
+// SyncedFromErrors returns ReconcileSuccess if all supplied errors are nil, otherwise a ReconcileError whose message
+// joins the messages of the non-nil errors with duplicates removed.
+func SyncedFromErrors(errs []error) Condition {
+	var msgs []string
+	seen := map[string]struct{}{}
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		msg := err.Error()
+		if _, ok := seen[msg]; ok {
+			continue
+		}
+		seen[msg] = struct{}{}
+		msgs = append(msgs, msg)
+	}
+
+	if len(msgs) == 0 {
+		return ReconcileSuccess()
 	}
+	return ReconcileError(errors.New(strings.Join(msgs, "; ")))
+}
+
+// ClassifyError returns a reason classifying the supplied error by its Kubernetes API error kind,
+// along with the error's message. Errors that aren't recognized API errors are classified as ReconcileError.
+// A nil error is classified as ReconcileSuccess with an empty message.
+func ClassifyError(err error) (ConditionReason, string) {
+	switch {
+	case err == nil:
+		return ReasonReconcileSuccess, ""
+	case apierrors.IsNotFound(err):
+		return ReasonReferenceNotFound, err.Error()
+	case apierrors.IsConflict(err):
+		return ReasonConflict, err.Error()
+	case apierrors.IsForbidden(err):
+		return ReasonForbidden, err.Error()
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		return ReasonTimeout, err.Error()
+	case apierrors.IsInvalid(err):
+		return ReasonInvalid, err.Error()
+	default:
+		return ReasonReconcileError, err.Error()
+	}
+}
+
+// DefaultRedactionPatterns match common credential formats that may appear in error messages:
+// bearer tokens, credentials embedded in URLs, credential query parameters, GitHub tokens, and AWS access key IDs.
+var DefaultRedactionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(bearer\s+)[a-z0-9\-._~+/]+=*`),
+	regexp.MustCompile(`(://[^/\s:@]+:)[^/\s@]+(@)`),
+	regexp.MustCompile(`(?i)((?:access_token|token|api_key|apikey|password|secret)=)[^&\s]+`),
+	regexp.MustCompile(`gh[pousr]_[A-Za-z0-9]{36,}`),
+	regexp.MustCompile(`AKIA[0-9A-Z]{16}`),
+}
+
+// RedactMessage replaces all matches of the supplied patterns in msg with "[REDACTED]". Text matched by a
+// pattern's first and second capture groups, if any, is preserved before and after the redaction respectively,
+// which lets patterns keep surrounding context such as a parameter name.
+func RedactMessage(msg string, patterns []*regexp.Regexp) string {
+	for _, p := range patterns {
+		msg = p.ReplaceAllString(msg, "${1}[REDACTED]${2}")
+	}
+	return msg
+}
+
+// ReconcileErrorRedacted returns a ReconcileError condition whose message has been redacted with the supplied
+// patterns, or with DefaultRedactionPatterns if none are supplied.
+func ReconcileErrorRedacted(err error, patterns ...*regexp.Regexp) Condition {
+	if len(patterns) == 0 {
+		patterns = DefaultRedactionPatterns
+	}
+	c := ReconcileError(err)
+	c.Message = RedactMessage(c.Message, patterns)
+	return c
 }
 
 // ReferencesValid returns a condition indicating that all object references
@@ -297,7 +1805,24 @@ func ReferencesValid() Condition {
 func ReferencesInvalid(reason ConditionReason, missingRefs []ObjectRef) Condition {
 	var missingRefStrings []string
 	for _, ref := range missingRefs {
-		missingRefStrings = append(missingRefStrings, ref.ObjectKey().String())
+		missingRefStrings = append(missingRefStrings, ref.String())
+	}
+
+	return Condition{
+		Type:               TypeReferencesValid,
+		LastTransitionTime: metav1.Now(),
+		Status:             corev1.ConditionFalse,
+		Reason:             reason,
+		Message:            fmt.Sprintf("Referenced objects are not found: %s", strings.Join(missingRefStrings, ", ")),
+	}
+}
+
+// ReferencesInvalidTyped returns a condition indicating that some typed object references
+// are invalid, i.e. that they reference non-existent objects.
+func ReferencesInvalidTyped(reason ConditionReason, missingRefs []TypedObjectRef) Condition {
+	var missingRefStrings []string
+	for _, ref := range missingRefs {
+		missingRefStrings = append(missingRefStrings, ref.String())
 	}
 
 	return Condition{
@@ -308,3 +1833,47 @@ func ReferencesInvalid(reason ConditionReason, missingRefs []ObjectRef) Conditio
 		Message:            fmt.Sprintf("Referenced objects are not found: %s", strings.Join(missingRefStrings, ", ")),
 	}
 }
+
+// ClusterReferencesInvalid returns a condition indicating that some multi-cluster object references
+// are invalid, i.e. that they reference non-existent objects. Missing refs are grouped by cluster in the message.
+func ClusterReferencesInvalid(reason ConditionReason, missingRefs []ClusterObjectRef) Condition {
+	byCluster := map[string][]string{}
+	var clusterIDs []string
+	for _, ref := range missingRefs {
+		if _, ok := byCluster[ref.ClusterID]; !ok {
+			clusterIDs = append(clusterIDs, ref.ClusterID)
+		}
+		byCluster[ref.ClusterID] = append(byCluster[ref.ClusterID], ref.String())
+	}
+	sort.Strings(clusterIDs)
+
+	var groups []string
+	for _, id := range clusterIDs {
+		groups = append(groups, fmt.Sprintf("cluster %s: %s", id, strings.Join(byCluster[id], ", ")))
+	}
+
+	return Condition{
+		Type:               TypeReferencesValid,
+		LastTransitionTime: metav1.Now(),
+		Status:             corev1.ConditionFalse,
+		Reason:             reason,
+		Message:            fmt.Sprintf("Referenced objects are not found: %s", strings.Join(groups, "; ")),
+	}
+}
+
+// MissingManagedCondition returns a condition indicating that some managed resources
+// tracked by the resource no longer exist.
+func MissingManagedCondition(missing []TypedObjectRef) Condition {
+	var missingRefStrings []string
+	for _, ref := range missing {
+		missingRefStrings = append(missingRefStrings, ref.String())
+	}
+
+	return Condition{
+		Type:               TypeManagedResourcesHealthy,
+		LastTransitionTime: metav1.Now(),
+		Status:             corev1.ConditionFalse,
+		Reason:             ReasonManagedResourcesMissing,
+		Message:            fmt.Sprintf("Managed resources are not found: %s", strings.Join(missingRefStrings, ", ")),
+	}
+}
@@ -0,0 +1,39 @@
+package event
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/reddit/achilles-sdk-api/api"
+)
+
+// Recorder records Kubernetes events. It is the subset of k8s.io/client-go/tools/record.EventRecorder used by
+// this package, so any record.EventRecorder may be supplied without this module depending on client-go.
+type Recorder interface {
+	// Event constructs an event from the given information and puts it in the queue for sending.
+	Event(object runtime.Object, eventtype, reason, message string)
+}
+
+// SetConditionsWithEvents sets the supplied conditions on the status and records an event on obj for each condition
+// whose status transitioned, including newly added conditions. Transitions to True are recorded as Normal events,
+// all other transitions as Warning events.
+func SetConditionsWithEvents(s *api.ConditionedStatus, recorder Recorder, obj runtime.Object, c ...api.Condition) {
+	old := make([]api.Condition, len(s.Conditions))
+	copy(old, s.Conditions)
+
+	s.SetConditions(c...)
+
+	for _, t := range api.DiffConditionsForEvents(old, s.Conditions) {
+		eventType := corev1.EventTypeWarning
+		if t.NewStatus == corev1.ConditionTrue {
+			eventType = corev1.EventTypeNormal
+		}
+		message := fmt.Sprintf("%s -> %s", t.Type, t.NewStatus)
+		if t.Message != "" {
+			message += ": " + t.Message
+		}
+		recorder.Event(obj, eventType, string(t.Reason), message)
+	}
+}
@@ -0,0 +1,54 @@
+package event
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/reddit/achilles-sdk-api/api"
+)
+
+// event is an event recorded by fakeRecorder.
+type event struct {
+	eventType, reason, message string
+}
+
+// fakeRecorder is a Recorder that records events in memory.
+type fakeRecorder struct {
+	events []event
+}
+
+func (r *fakeRecorder) Event(_ runtime.Object, eventType, reason, message string) {
+	r.events = append(r.events, event{eventType: eventType, reason: reason, message: message})
+}
+
+func TestSetConditionsWithEvents(t *testing.T) {
+	obj := &corev1.ConfigMap{}
+	s := api.NewConditionedStatus(api.Creating(), api.ReconcileSuccess())
+	recorder := &fakeRecorder{}
+
+	SetConditionsWithEvents(s, recorder, obj,
+		api.Available(),
+		api.ReconcileSuccess(),
+		api.ReferencesInvalid(api.ReasonReferenceNotFound, []api.ObjectRef{{Namespace: "default", Name: "foo"}}),
+	)
+
+	want := []event{
+		{eventType: corev1.EventTypeNormal, reason: "Available", message: "Ready -> True"},
+		{eventType: corev1.EventTypeWarning, reason: "ReferenceNotFound", message: "ReferencesValid -> False: Referenced objects are not found: default/foo"},
+	}
+	if !reflect.DeepEqual(recorder.events, want) {
+		t.Errorf("recorded events = %+v, want %+v", recorder.events, want)
+	}
+	if !s.IsReady() {
+		t.Errorf("Ready = %v, want the conditions to be set", s.GetCondition(api.TypeReady))
+	}
+
+	recorder.events = nil
+	SetConditionsWithEvents(s, recorder, obj, api.Available().WithMessage("still up"))
+	if len(recorder.events) != 0 {
+		t.Errorf("recorded events = %+v for a condition that did not transition, want none", recorder.events)
+	}
+}
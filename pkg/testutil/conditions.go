@@ -0,0 +1,66 @@
+package testutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/reddit/achilles-sdk-api/api"
+)
+
+// AssertConditionsEqual fails the test if the statuses are not equal, ignoring LastTransitionTimes and the order of
+// conditions. The failure message lists each missing, unexpected, and mismatched condition.
+func AssertConditionsEqual(t testing.TB, want, got *api.ConditionedStatus) {
+	t.Helper()
+
+	if want.Equal(got) {
+		return
+	}
+	if want == nil || got == nil {
+		t.Errorf("conditioned statuses differ: want %v, got %v", want, got)
+		return
+	}
+
+	wantByType := map[api.ConditionType]api.Condition{}
+	for _, c := range want.Conditions {
+		wantByType[c.Type] = c
+	}
+	gotByType := map[api.ConditionType]api.Condition{}
+	for _, c := range got.Conditions {
+		gotByType[c.Type] = c
+	}
+
+	var diffs []string
+	for ct, w := range wantByType {
+		g, ok := gotByType[ct]
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("missing %s: want %s", ct, formatCondition(w)))
+		case !w.Equal(g):
+			diffs = append(diffs, fmt.Sprintf("mismatched %s:\n\t\twant %s\n\t\tgot  %s", ct, formatCondition(w), formatCondition(g)))
+		}
+	}
+	for ct, g := range gotByType {
+		if _, ok := wantByType[ct]; !ok {
+			diffs = append(diffs, fmt.Sprintf("unexpected %s: got %s", ct, formatCondition(g)))
+		}
+	}
+	if len(diffs) == 0 {
+		// the statuses differ only by duplicate condition types
+		diffs = append(diffs, fmt.Sprintf("want %d conditions, got %d", len(want.Conditions), len(got.Conditions)))
+	}
+	sort.Strings(diffs)
+
+	t.Errorf("conditions differ (ignoring time and order):\n\t%s", strings.Join(diffs, "\n\t"))
+}
+
+// formatCondition formats the fields of c compared by api.Condition.Equal, and its TransitionCount if it is set.
+func formatCondition(c api.Condition) string {
+	s := fmt.Sprintf("{Status: %s, Reason: %s, Message: %q, ObservedGeneration: %d, Severity: %q",
+		c.Status, c.Reason, c.Message, c.ObservedGeneration, c.Severity)
+	if c.TransitionCount != 0 {
+		s += fmt.Sprintf(", TransitionCount: %d", c.TransitionCount)
+	}
+	return s + "}"
+}
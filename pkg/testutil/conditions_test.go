@@ -0,0 +1,98 @@
+package testutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/reddit/achilles-sdk-api/api"
+)
+
+// recordingTB is a testing.TB that records errors instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (t *recordingTB) Helper() {}
+
+func (t *recordingTB) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertConditionsEqual(t *testing.T) {
+	want := api.NewConditionedStatus(api.Available(), api.ReconcileSuccess())
+
+	tests := []struct {
+		name         string
+		got          *api.ConditionedStatus
+		wantContains []string
+	}{
+		{
+			name: "equal ignoring time and order",
+			got: &api.ConditionedStatus{Conditions: []api.Condition{
+				api.ReconcileSuccess(),
+				func() api.Condition {
+					c := api.Available()
+					c.LastTransitionTime = metav1.Unix(100, 0)
+					return c
+				}(),
+			}},
+		},
+		{
+			name:         "missing",
+			got:          api.NewConditionedStatus(api.Available()),
+			wantContains: []string{"missing Synced"},
+		},
+		{
+			name:         "unexpected",
+			got:          api.NewConditionedStatus(api.Available(), api.ReconcileSuccess(), api.ReferencesValid()),
+			wantContains: []string{"unexpected ReferencesValid"},
+		},
+		{
+			name:         "mismatched",
+			got:          api.NewConditionedStatus(api.Unavailable(), api.ReconcileSuccess()),
+			wantContains: []string{"mismatched Ready", "Reason: Unavailable"},
+		},
+		{
+			name: "mismatched severity",
+			got: api.NewConditionedStatus(
+				func() api.Condition {
+					c := api.Available().WithSeverity(api.SeverityWarning)
+					c.TransitionCount = 2
+					return c
+				}(),
+				api.ReconcileSuccess(),
+			),
+			wantContains: []string{"mismatched Ready", `Severity: "Warning", TransitionCount: 2}`},
+		},
+		{
+			name:         "nil",
+			got:          nil,
+			wantContains: []string{"conditioned statuses differ"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &recordingTB{}
+			AssertConditionsEqual(tb, want, tt.got)
+
+			if len(tt.wantContains) == 0 {
+				if len(tb.errors) != 0 {
+					t.Errorf("AssertConditionsEqual() reported %q, want no errors", tb.errors)
+				}
+				return
+			}
+			if len(tb.errors) != 1 {
+				t.Fatalf("AssertConditionsEqual() reported %d errors, want 1", len(tb.errors))
+			}
+			for _, s := range tt.wantContains {
+				if !strings.Contains(tb.errors[0], s) {
+					t.Errorf("AssertConditionsEqual() reported %q, want it to contain %q", tb.errors[0], s)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package trace
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/reddit/achilles-sdk-api/api"
+)
+
+// ConditionAttributes returns the status of each condition keyed by "condition.<type>", with the type lowercased,
+// e.g. "condition.ready" -> "True". Use SetSpanConditions to record them on a trace span. Returns an empty map if s
+// is nil.
+func ConditionAttributes(s *api.ConditionedStatus) map[string]string {
+	attrs := map[string]string{}
+	if s == nil {
+		return attrs
+	}
+	for _, c := range s.Conditions {
+		attrs[attributeKey(c.Type)] = string(c.Status)
+	}
+	return attrs
+}
+
+// SetSpanConditions records the status of each condition on span as a string attribute, keyed as in
+// ConditionAttributes. It does nothing if s is nil.
+func SetSpanConditions(span trace.Span, s *api.ConditionedStatus) {
+	if s == nil {
+		return
+	}
+	attrs := make([]attribute.KeyValue, 0, len(s.Conditions))
+	for _, c := range s.Conditions {
+		attrs = append(attrs, attribute.String(attributeKey(c.Type), string(c.Status)))
+	}
+	span.SetAttributes(attrs...)
+}
+
+// attributeKey returns the attribute key for a condition type.
+func attributeKey(t api.ConditionType) string {
+	return "condition." + strings.ToLower(t.String())
+}
@@ -0,0 +1,51 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/reddit/achilles-sdk-api/api"
+)
+
+func TestConditionAttributes(t *testing.T) {
+	s := api.NewConditionedStatus(api.Available(), api.ReconcileError(errors.New("boom")))
+
+	want := map[string]string{
+		"condition.ready":  "True",
+		"condition.synced": "False",
+	}
+	if got := ConditionAttributes(s); !reflect.DeepEqual(got, want) {
+		t.Errorf("ConditionAttributes() = %v, want %v", got, want)
+	}
+	if got := ConditionAttributes(nil); len(got) != 0 {
+		t.Errorf("ConditionAttributes(nil) = %v, want empty", got)
+	}
+}
+
+func TestSetSpanConditions(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "reconcile")
+	SetSpanConditions(span, api.NewConditionedStatus(api.Available(), api.ReconcileError(errors.New("boom"))))
+	SetSpanConditions(span, nil)
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+	want := []attribute.KeyValue{
+		attribute.String("condition.ready", "True"),
+		attribute.String("condition.synced", "False"),
+	}
+	if got := spans[0].Attributes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("span attributes = %v, want %v", got, want)
+	}
+}
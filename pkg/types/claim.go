@@ -0,0 +1,132 @@
+package types
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/reddit/achilles-sdk-api/api"
+)
+
+// IsClaimed returns true if the claimed resource references a claim.
+func IsClaimed(claimed ClaimedResource) bool {
+	ref := claimed.GetClaimRef()
+	return ref != nil && !ref.ObjectKeyNotSet()
+}
+
+// IsBound returns true if the claim references a claimed resource.
+func IsBound(claim ClaimResource) bool {
+	ref := claim.GetClaimedRef()
+	return ref != nil && !ref.ObjectKeyNotSet()
+}
+
+// ClaimRefMatches returns true if the claimed resource's claim ref is identical to the expected ref.
+// A nil claim ref never matches.
+func ClaimRefMatches(claimed ClaimedResource, expected api.TypedObjectRef) bool {
+	ref := claimed.GetClaimRef()
+	return ref != nil && *ref == expected
+}
+
+// FindDuplicateClaims groups claimed resources that reference the same claim, keyed by the claim ref's string form.
+// Only claims referenced by more than one claimed resource are returned, since a claim should bind exactly one
+// claimed resource. Claimed resources without a claim ref are ignored.
+func FindDuplicateClaims(claimedList []ClaimedResource) map[string][]ClaimedResource {
+	byClaim := map[string][]ClaimedResource{}
+	for _, claimed := range claimedList {
+		if !IsClaimed(claimed) {
+			continue
+		}
+		key := claimed.GetClaimRef().String()
+		byClaim[key] = append(byClaim[key], claimed)
+	}
+
+	for key, group := range byClaim {
+		if len(group) < 2 {
+			delete(byClaim, key)
+		}
+	}
+	return byClaim
+}
+
+// MirrorClaimedStatus copies the claimed resource's Ready and Synced conditions onto the claim. Conditions
+// absent from the claimed resource are mirrored as Unknown. typeMap maps a claimed condition type to the type it is
+// mirrored as on the claim, e.g. to mirror the claimed resource's Ready condition as the claim's "BackendReady"
+// condition. Types not in typeMap, or all types if typeMap is nil, are mirrored as the same type.
+func MirrorClaimedStatus(claim api.Conditioned, claimed api.Conditioned, typeMap map[api.ConditionType]api.ConditionType) {
+	for _, ct := range []api.ConditionType{api.TypeReady, api.TypeSynced} {
+		c := claimed.GetCondition(ct)
+		if mapped, ok := typeMap[ct]; ok {
+			c.Type = mapped
+		}
+		if c.LastTransitionTime.IsZero() {
+			c.LastTransitionTime = metav1.Now()
+		}
+		claim.SetConditions(c)
+	}
+}
+
+// ValidateClaimNamespace returns an error if requireSame is true and the claim and claimed objects are in different
+// namespaces. A cluster-scoped object has an empty namespace and so only matches another cluster-scoped object.
+func ValidateClaimNamespace(claim, claimed client.Object, requireSame bool) error {
+	if requireSame && claim.GetNamespace() != claimed.GetNamespace() {
+		return fmt.Errorf("claim namespace %q does not match claimed namespace %q", claim.GetNamespace(), claimed.GetNamespace())
+	}
+	return nil
+}
+
+// ClaimPropagationLag returns the number of claim generations not yet observed by the claimed resource, computed as
+// the claim's generation minus the observed generation of the claimed resource's Synced condition. A lag of zero
+// means the claimed resource is in sync with the claim.
+func ClaimPropagationLag(claim, claimed api.Conditioned) int64 {
+	return claim.GetGeneration() - claimed.GetCondition(api.TypeSynced).ObservedGeneration
+}
+
+// ValidateClaimPairing returns an error if the claim kind is not allowed to bind the claimed kind.
+// allowed maps each permitted claim GVK to the claimed GVK it may bind.
+func ValidateClaimPairing(claim, claimed schema.GroupVersionKind, allowed map[schema.GroupVersionKind]schema.GroupVersionKind) error {
+	allowedClaimed, ok := allowed[claim]
+	if !ok {
+		return fmt.Errorf("claim kind %s is not allowed", claim)
+	}
+	if allowedClaimed != claimed {
+		return fmt.Errorf("claim kind %s may only bind %s, not %s", claim, allowedClaimed, claimed)
+	}
+	return nil
+}
+
+// EstablishClaim binds the claim and claimed resources to each other by setting the claim's claimed ref and the
+// claimed resource's claim ref. claimObj and claimedObj are the objects backing claim and claimed, whose GVKs are
+// resolved via the scheme. An error is returned without modifying either resource if the claim references itself,
+// if either GVK cannot be resolved, or if either resource is already bound to a different resource.
+func EstablishClaim(
+	claim ClaimResource,
+	claimed ClaimedResource,
+	claimObj, claimedObj client.Object,
+	scheme *runtime.Scheme,
+) error {
+	claimRef, err := api.TypedObjectRefFromObject(claimObj, scheme)
+	if err != nil {
+		return fmt.Errorf("resolving claim ref: %w", err)
+	}
+	claimedRef, err := api.TypedObjectRefFromObject(claimedObj, scheme)
+	if err != nil {
+		return fmt.Errorf("resolving claimed ref: %w", err)
+	}
+
+	if claimRef.Equal(*claimedRef) {
+		return fmt.Errorf("claim %s cannot claim itself", claimRef)
+	}
+	if existing := claim.GetClaimedRef(); existing != nil && !existing.ObjectKeyNotSet() && !existing.Equal(*claimedRef) {
+		return fmt.Errorf("claim %s is already bound to %s", claimRef, existing)
+	}
+	if existing := claimed.GetClaimRef(); existing != nil && !existing.ObjectKeyNotSet() && !existing.Equal(*claimRef) {
+		return fmt.Errorf("claimed %s is already bound to %s", claimedRef, existing)
+	}
+
+	claim.SetClaimedRef(claimedRef)
+	claimed.SetClaimRef(claimRef)
+	return nil
+}
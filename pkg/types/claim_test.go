@@ -0,0 +1,297 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/reddit/achilles-sdk-api/api"
+)
+
+func TestValidateClaimPairing(t *testing.T) {
+	claimGVK := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "DatabaseClaim"}
+	claimedGVK := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Database"}
+	otherGVK := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Cache"}
+	allowed := map[schema.GroupVersionKind]schema.GroupVersionKind{claimGVK: claimedGVK}
+
+	tests := []struct {
+		name    string
+		claim   schema.GroupVersionKind
+		claimed schema.GroupVersionKind
+		wantErr bool
+	}{
+		{name: "allowed", claim: claimGVK, claimed: claimedGVK},
+		{name: "claim kind not allowed", claim: otherGVK, claimed: claimedGVK, wantErr: true},
+		{name: "claimed kind not allowed for claim", claim: claimGVK, claimed: otherGVK, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateClaimPairing(tt.claim, tt.claimed, allowed); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateClaimPairing() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// fakeClaimed is a minimal ClaimedResource.
+type fakeClaimed struct {
+	claimRef *api.TypedObjectRef
+}
+
+func (c *fakeClaimed) GetClaimRef() *api.TypedObjectRef    { return c.claimRef }
+func (c *fakeClaimed) SetClaimRef(ref *api.TypedObjectRef) { c.claimRef = ref }
+
+// fakeClaim is a minimal ClaimResource.
+type fakeClaim struct {
+	claimedRef *api.TypedObjectRef
+}
+
+func (c *fakeClaim) GetClaimedRef() *api.TypedObjectRef    { return c.claimedRef }
+func (c *fakeClaim) SetClaimedRef(ref *api.TypedObjectRef) { c.claimedRef = ref }
+
+func TestIsClaimedAndIsBound(t *testing.T) {
+	populated := configMapRef("default", "foo")
+
+	tests := []struct {
+		name string
+		ref  *api.TypedObjectRef
+		want bool
+	}{
+		{name: "nil ref", ref: nil, want: false},
+		{name: "empty ref", ref: &api.TypedObjectRef{Version: "v1", Kind: "ConfigMap"}, want: false},
+		{name: "populated ref", ref: &populated, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsClaimed(&fakeClaimed{claimRef: tt.ref}); got != tt.want {
+				t.Errorf("IsClaimed() = %t, want %t", got, tt.want)
+			}
+			if got := IsBound(&fakeClaim{claimedRef: tt.ref}); got != tt.want {
+				t.Errorf("IsBound() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClaimRefMatches(t *testing.T) {
+	expected := configMapRef("default", "claim")
+	other := configMapRef("default", "other")
+
+	tests := []struct {
+		name     string
+		claimRef *api.TypedObjectRef
+		want     bool
+	}{
+		{name: "match", claimRef: &expected, want: true},
+		{name: "mismatch", claimRef: &other, want: false},
+		{name: "nil", claimRef: nil, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClaimRefMatches(&fakeClaimed{claimRef: tt.claimRef}, expected); got != tt.want {
+				t.Errorf("ClaimRefMatches() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstablishClaim(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	claimObj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "claim"}}
+	claimedObj := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "claimed"}}
+	claimRef := api.TypedObjectRef{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "claim"}
+	claimedRef := api.TypedObjectRef{Version: "v1", Kind: "Secret", Namespace: "default", Name: "claimed"}
+	otherRef := api.TypedObjectRef{Version: "v1", Kind: "Secret", Namespace: "default", Name: "other"}
+
+	t.Run("success", func(t *testing.T) {
+		claim, claimed := &fakeClaim{}, &fakeClaimed{}
+		if err := EstablishClaim(claim, claimed, claimObj, claimedObj, scheme); err != nil {
+			t.Fatalf("EstablishClaim() error = %v", err)
+		}
+		if claim.claimedRef == nil || *claim.claimedRef != claimedRef {
+			t.Errorf("claimed ref = %v, want %v", claim.claimedRef, claimedRef)
+		}
+		if claimed.claimRef == nil || *claimed.claimRef != claimRef {
+			t.Errorf("claim ref = %v, want %v", claimed.claimRef, claimRef)
+		}
+	})
+
+	t.Run("already bound to the same resources", func(t *testing.T) {
+		claim := &fakeClaim{claimedRef: &claimedRef}
+		claimed := &fakeClaimed{claimRef: &claimRef}
+		if err := EstablishClaim(claim, claimed, claimObj, claimedObj, scheme); err != nil {
+			t.Errorf("EstablishClaim() error = %v, want rebinding to be idempotent", err)
+		}
+	})
+
+	failures := []struct {
+		name       string
+		claim      *fakeClaim
+		claimed    *fakeClaimed
+		claimedObj *corev1.Secret
+		scheme     *runtime.Scheme
+	}{
+		{
+			name:    "scheme resolution failure",
+			claim:   &fakeClaim{},
+			claimed: &fakeClaimed{},
+			scheme:  runtime.NewScheme(),
+		},
+		{
+			name:    "claim bound to another resource",
+			claim:   &fakeClaim{claimedRef: &otherRef},
+			claimed: &fakeClaimed{},
+			scheme:  scheme,
+		},
+		{
+			name:    "claimed bound to another claim",
+			claim:   &fakeClaim{},
+			claimed: &fakeClaimed{claimRef: &otherRef},
+			scheme:  scheme,
+		},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			claimRefBefore, claimedRefBefore := tt.claimed.claimRef, tt.claim.claimedRef
+			if err := EstablishClaim(tt.claim, tt.claimed, claimObj, claimedObj, tt.scheme); err == nil {
+				t.Fatal("EstablishClaim() succeeded, want error")
+			}
+			if tt.claimed.claimRef != claimRefBefore || tt.claim.claimedRef != claimedRefBefore {
+				t.Error("EstablishClaim() modified a resource despite failing")
+			}
+		})
+	}
+
+	t.Run("self-reference", func(t *testing.T) {
+		claim, claimed := &fakeClaim{}, &fakeClaimed{}
+		if err := EstablishClaim(claim, claimed, claimObj, claimObj, scheme); err == nil {
+			t.Fatal("EstablishClaim() succeeded, want error for a claim claiming itself")
+		}
+		if claim.claimedRef != nil || claimed.claimRef != nil {
+			t.Error("EstablishClaim() modified a resource despite failing")
+		}
+	})
+}
+
+func TestFindDuplicateClaims(t *testing.T) {
+	shared := configMapRef("default", "shared")
+	unique := configMapRef("default", "unique")
+
+	first := &fakeClaimed{claimRef: &shared}
+	second := &fakeClaimed{claimRef: &shared}
+	third := &fakeClaimed{claimRef: &unique}
+	unclaimed := &fakeClaimed{}
+
+	want := map[string][]ClaimedResource{shared.String(): {first, second}}
+	if got := FindDuplicateClaims([]ClaimedResource{first, third, unclaimed, second}); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindDuplicateClaims() = %v, want %v", got, want)
+	}
+}
+
+// conditioned is a minimal api.Conditioned.
+type conditioned struct {
+	api.ConditionedStatus
+	generation int64
+}
+
+func (c *conditioned) GetGeneration() int64 { return c.generation }
+
+func TestMirrorClaimedStatus(t *testing.T) {
+	t.Run("present", func(t *testing.T) {
+		ready := api.Unavailable().WithMessage("api reports unhealthy")
+		claimed := &conditioned{ConditionedStatus: *api.NewConditionedStatus(ready, api.ReconcileSuccess())}
+		claim := &conditioned{ConditionedStatus: *api.NewConditionedStatus(api.Creating())}
+
+		MirrorClaimedStatus(claim, claimed, nil)
+
+		if !claim.Equal(&claimed.ConditionedStatus) {
+			t.Errorf("claim conditions = %v, want %v", claim.Conditions, claimed.Conditions)
+		}
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		claim := &conditioned{}
+		MirrorClaimedStatus(claim, &conditioned{}, nil)
+
+		for _, ct := range []api.ConditionType{api.TypeReady, api.TypeSynced} {
+			c := claim.GetCondition(ct)
+			if c.Status != corev1.ConditionUnknown || c.LastTransitionTime.IsZero() {
+				t.Errorf("claim %v = %v at %v, want Unknown with a last transition time", ct, c, c.LastTransitionTime)
+			}
+		}
+	})
+
+	t.Run("mapped type", func(t *testing.T) {
+		ready := api.Unavailable().WithMessage("api reports unhealthy")
+		claimed := &conditioned{ConditionedStatus: *api.NewConditionedStatus(ready, api.ReconcileSuccess())}
+		claim := &conditioned{ConditionedStatus: *api.NewConditionedStatus(api.Available())}
+
+		MirrorClaimedStatus(claim, claimed, map[api.ConditionType]api.ConditionType{api.TypeReady: "BackendReady"})
+
+		if got := claim.GetCondition("BackendReady"); got.Status != corev1.ConditionFalse || got.Message != ready.Message {
+			t.Errorf("claim BackendReady = %v, want the claimed Ready condition %v", got, ready)
+		}
+		if !claim.IsReady() {
+			t.Errorf("claim Ready = %v, want it left untouched", claim.GetCondition(api.TypeReady))
+		}
+		if got := claim.GetCondition(api.TypeSynced); got.Status != corev1.ConditionTrue {
+			t.Errorf("claim Synced = %v, want the claimed Synced condition", got)
+		}
+	})
+}
+
+func TestValidateClaimNamespace(t *testing.T) {
+	inDefault := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}}
+	inOther := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "b"}}
+	clusterScoped := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "c"}}
+
+	tests := []struct {
+		name           string
+		claim, claimed client.Object
+		requireSame    bool
+		wantErr        bool
+	}{
+		{name: "same namespace", claim: inDefault, claimed: inDefault, requireSame: true},
+		{name: "different namespaces", claim: inDefault, claimed: inOther, requireSame: true, wantErr: true},
+		{name: "different namespaces allowed", claim: inDefault, claimed: inOther},
+		{name: "cluster-scoped claimed", claim: inDefault, claimed: clusterScoped, requireSame: true, wantErr: true},
+		{name: "both cluster-scoped", claim: clusterScoped, claimed: clusterScoped, requireSame: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateClaimNamespace(tt.claim, tt.claimed, tt.requireSame); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateClaimNamespace() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClaimPropagationLag(t *testing.T) {
+	tests := []struct {
+		name            string
+		claimGeneration int64
+		synced          []api.Condition
+		want            int64
+	}{
+		{name: "in sync", claimGeneration: 3, synced: []api.Condition{api.ReconcileSuccess().WithObservedGeneration(3)}, want: 0},
+		{name: "lagging", claimGeneration: 5, synced: []api.Condition{api.ReconcileSuccess().WithObservedGeneration(3)}, want: 2},
+		{name: "never synced", claimGeneration: 2, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := &conditioned{generation: tt.claimGeneration}
+			claimed := &conditioned{ConditionedStatus: *api.NewConditionedStatus(tt.synced...)}
+			if got := ClaimPropagationLag(claim, claimed); got != tt.want {
+				t.Errorf("ClaimPropagationLag() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package types
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/reddit/achilles-sdk-api/api"
+)
+
+// GroupManagedByNamespace groups managed resource refs by namespace, preserving input order within each group.
+// Cluster-scoped refs are grouped under the empty namespace.
+func GroupManagedByNamespace(refs []api.TypedObjectRef) map[string][]api.TypedObjectRef {
+	groups := map[string][]api.TypedObjectRef{}
+	for _, ref := range refs {
+		groups[ref.Namespace] = append(groups[ref.Namespace], ref)
+	}
+	return groups
+}
+
+// FilterManagedByScheme partitions managed resource refs into those whose GVK is recognized by the scheme and those
+// whose GVK is not, e.g. kinds that were dropped from the controller's scheme in an upgrade. Input order is preserved.
+func FilterManagedByScheme(refs []api.TypedObjectRef, scheme *runtime.Scheme) (known, unknown []api.TypedObjectRef) {
+	for _, ref := range refs {
+		if scheme.Recognizes(ref.GroupVersionKind()) {
+			known = append(known, ref)
+		} else {
+			unknown = append(unknown, ref)
+		}
+	}
+	return known, unknown
+}
+
+// ManagedResourcesOfKind returns the managed resource refs with the supplied GVK, preserving input order.
+func ManagedResourcesOfKind(refs []api.TypedObjectRef, gvk schema.GroupVersionKind) []api.TypedObjectRef {
+	var matched []api.TypedObjectRef
+	for _, ref := range refs {
+		if ref.GroupVersionKind() == gvk {
+			matched = append(matched, ref)
+		}
+	}
+	return matched
+}
+
+// CanonicalManagedResources returns a sorted, deduplicated copy of the managed resource refs. Storing the canonical
+// form in status keeps its serialization stable across reconciles so that server and client agree.
+func CanonicalManagedResources(refs []api.TypedObjectRef) []api.TypedObjectRef {
+	seen := make(map[api.TypedObjectRef]struct{}, len(refs))
+	canonical := make([]api.TypedObjectRef, 0, len(refs))
+	for _, ref := range refs {
+		if _, ok := seen[ref]; ok {
+			continue
+		}
+		seen[ref] = struct{}{}
+		canonical = append(canonical, ref)
+	}
+
+	sort.Slice(canonical, func(i, j int) bool { return canonical[i].Less(canonical[j]) })
+	return canonical
+}
+
+// ManagedResourceChurn compares the old and new sets of managed resource refs, returning the number of refs that were
+// added, removed, and unchanged. Duplicate refs are counted once.
+func ManagedResourceChurn(old, new []api.TypedObjectRef) (added, removed, unchanged int) {
+	oldSet := make(map[api.TypedObjectRef]struct{}, len(old))
+	for _, ref := range old {
+		oldSet[ref] = struct{}{}
+	}
+	newSet := make(map[api.TypedObjectRef]struct{}, len(new))
+	for _, ref := range new {
+		newSet[ref] = struct{}{}
+	}
+
+	for ref := range newSet {
+		if _, ok := oldSet[ref]; ok {
+			unchanged++
+		} else {
+			added++
+		}
+	}
+	removed = len(oldSet) - unchanged
+	return added, removed, unchanged
+}
+
+// ManagedResourcesDrifted returns true if the current and desired sets of managed resource refs differ,
+// ignoring order and duplicates.
+func ManagedResourcesDrifted(current, desired []api.TypedObjectRef) bool {
+	added, removed, _ := ManagedResourceChurn(current, desired)
+	return added > 0 || removed > 0
+}
+
+// ShouldGC returns true if the managed resource ref is not in the desired set, i.e. it is orphaned and should be
+// garbage collected.
+func ShouldGC(ref api.TypedObjectRef, desired []api.TypedObjectRef) bool {
+	for _, d := range desired {
+		if d.Equal(ref) {
+			return false
+		}
+	}
+	return true
+}
+
+// PlanClusterManagedChanges compares the current and desired sets of multi-cluster managed resource refs by cluster,
+// GVK, and object key, returning the refs that must be created and the refs that must be deleted.
+// Both results are sorted by cluster, then by GVK, then by object key.
+func PlanClusterManagedChanges(current, desired []api.TypedClusterObjectRef) (toCreate, toDelete []api.TypedClusterObjectRef) {
+	currentSet := make(map[api.TypedClusterObjectRef]struct{}, len(current))
+	for _, ref := range current {
+		currentSet[ref] = struct{}{}
+	}
+	desiredSet := make(map[api.TypedClusterObjectRef]struct{}, len(desired))
+	for _, ref := range desired {
+		desiredSet[ref] = struct{}{}
+	}
+
+	for ref := range desiredSet {
+		if _, ok := currentSet[ref]; !ok {
+			toCreate = append(toCreate, ref)
+		}
+	}
+	for ref := range currentSet {
+		if _, ok := desiredSet[ref]; !ok {
+			toDelete = append(toDelete, ref)
+		}
+	}
+
+	sortClusterRefs(toCreate)
+	sortClusterRefs(toDelete)
+	return toCreate, toDelete
+}
+
+func sortClusterRefs(refs []api.TypedClusterObjectRef) {
+	sort.Slice(refs, func(i, j int) bool { return refs[i].Less(refs[j]) })
+}
+
+// ManagedResourceSet is a set of managed resource refs, deduplicated by GVK and object key.
+// The zero value is an empty set ready for use.
+type ManagedResourceSet struct {
+	refs []api.TypedObjectRef
+}
+
+// NewManagedResourceSet returns a ManagedResourceSet containing the supplied refs.
+func NewManagedResourceSet(refs ...api.TypedObjectRef) *ManagedResourceSet {
+	s := &ManagedResourceSet{}
+	for _, ref := range refs {
+		s.Add(ref)
+	}
+	return s
+}
+
+// ManagedResourceSetFrom returns a ManagedResourceSet containing the managed resources of the ResourceManager.
+func ManagedResourceSetFrom(rm ResourceManager) *ManagedResourceSet {
+	return NewManagedResourceSet(rm.GetManagedResources()...)
+}
+
+// ApplyTo sets the managed resources of the ResourceManager to the members of the set.
+func (s *ManagedResourceSet) ApplyTo(rm ResourceManager) {
+	rm.SetManagedResources(s.List())
+}
+
+// Add adds the ref to the set, returning false if it was already a member.
+func (s *ManagedResourceSet) Add(ref api.TypedObjectRef) bool {
+	if s.Contains(ref) {
+		return false
+	}
+	s.refs = append(s.refs, ref)
+	return true
+}
+
+// Remove removes the ref from the set, returning false if it was not a member.
+func (s *ManagedResourceSet) Remove(ref api.TypedObjectRef) bool {
+	for i, member := range s.refs {
+		if member.Equal(ref) {
+			s.refs = append(s.refs[:i], s.refs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Contains returns true if the ref is a member of the set.
+func (s *ManagedResourceSet) Contains(ref api.TypedObjectRef) bool {
+	for _, member := range s.refs {
+		if member.Equal(ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the members of the set sorted by TypedObjectRef.Less.
+func (s *ManagedResourceSet) List() []api.TypedObjectRef {
+	return CanonicalManagedResources(s.refs)
+}
+
+// Len returns the number of members of the set.
+func (s *ManagedResourceSet) Len() int {
+	return len(s.refs)
+}
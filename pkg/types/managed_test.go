@@ -0,0 +1,247 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/reddit/achilles-sdk-api/api"
+)
+
+// configMapRef returns a ref to the ConfigMap with the supplied namespace and name.
+func configMapRef(namespace, name string) api.TypedObjectRef {
+	return api.TypedObjectRef{Version: "v1", Kind: "ConfigMap", Namespace: namespace, Name: name}
+}
+
+func TestGroupManagedByNamespace(t *testing.T) {
+	a1 := configMapRef("ns-a", "one")
+	a2 := configMapRef("ns-a", "two")
+	b1 := configMapRef("ns-b", "one")
+	clusterRole := api.TypedObjectRef{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole", Name: "admin"}
+
+	want := map[string][]api.TypedObjectRef{
+		"ns-a": {a1, a2},
+		"ns-b": {b1},
+		"":     {clusterRole},
+	}
+	if got := GroupManagedByNamespace([]api.TypedObjectRef{a1, clusterRole, b1, a2}); !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupManagedByNamespace() = %v, want %v", got, want)
+	}
+}
+
+func TestFilterManagedByScheme(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	configMap := configMapRef("default", "foo")
+	widget := api.TypedObjectRef{Group: "example.com", Version: "v1", Kind: "Widget", Namespace: "default", Name: "foo"}
+	secret := api.TypedObjectRef{Version: "v1", Kind: "Secret", Namespace: "default", Name: "foo"}
+
+	known, unknown := FilterManagedByScheme([]api.TypedObjectRef{configMap, widget, secret}, scheme)
+	if want := []api.TypedObjectRef{configMap, secret}; !reflect.DeepEqual(known, want) {
+		t.Errorf("known = %v, want %v", known, want)
+	}
+	if want := []api.TypedObjectRef{widget}; !reflect.DeepEqual(unknown, want) {
+		t.Errorf("unknown = %v, want %v", unknown, want)
+	}
+}
+
+func TestPlanClusterManagedChanges(t *testing.T) {
+	ref := func(clusterID, name string) api.TypedClusterObjectRef {
+		return api.TypedClusterObjectRef{TypedObjectRef: configMapRef("default", name), ClusterID: clusterID}
+	}
+
+	tests := []struct {
+		name         string
+		current      []api.TypedClusterObjectRef
+		desired      []api.TypedClusterObjectRef
+		wantToCreate []api.TypedClusterObjectRef
+		wantToDelete []api.TypedClusterObjectRef
+	}{
+		{
+			name:         "scale up to another cluster",
+			current:      []api.TypedClusterObjectRef{ref("cluster-a", "foo")},
+			desired:      []api.TypedClusterObjectRef{ref("cluster-b", "foo"), ref("cluster-a", "foo"), ref("cluster-c", "foo")},
+			wantToCreate: []api.TypedClusterObjectRef{ref("cluster-b", "foo"), ref("cluster-c", "foo")},
+		},
+		{
+			name:         "scale down from a cluster",
+			current:      []api.TypedClusterObjectRef{ref("cluster-b", "foo"), ref("cluster-a", "foo"), ref("cluster-b", "bar")},
+			desired:      []api.TypedClusterObjectRef{ref("cluster-a", "foo")},
+			wantToDelete: []api.TypedClusterObjectRef{ref("cluster-b", "bar"), ref("cluster-b", "foo")},
+		},
+		{
+			name:         "move between clusters",
+			current:      []api.TypedClusterObjectRef{ref("cluster-a", "foo")},
+			desired:      []api.TypedClusterObjectRef{ref("cluster-b", "foo")},
+			wantToCreate: []api.TypedClusterObjectRef{ref("cluster-b", "foo")},
+			wantToDelete: []api.TypedClusterObjectRef{ref("cluster-a", "foo")},
+		},
+		{
+			name:    "steady state",
+			current: []api.TypedClusterObjectRef{ref("cluster-a", "foo"), ref("cluster-b", "foo")},
+			desired: []api.TypedClusterObjectRef{ref("cluster-b", "foo"), ref("cluster-a", "foo")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toCreate, toDelete := PlanClusterManagedChanges(tt.current, tt.desired)
+			if !reflect.DeepEqual(toCreate, tt.wantToCreate) {
+				t.Errorf("toCreate = %v, want %v", toCreate, tt.wantToCreate)
+			}
+			if !reflect.DeepEqual(toDelete, tt.wantToDelete) {
+				t.Errorf("toDelete = %v, want %v", toDelete, tt.wantToDelete)
+			}
+		})
+	}
+}
+
+func TestCanonicalManagedResources(t *testing.T) {
+	a := configMapRef("default", "a")
+	b := configMapRef("default", "b")
+	other := configMapRef("other", "a")
+	deployment := api.TypedObjectRef{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "a"}
+
+	input := []api.TypedObjectRef{deployment, b, other, a, b}
+	original := append([]api.TypedObjectRef{}, input...)
+
+	canonical := CanonicalManagedResources(input)
+	if want := []api.TypedObjectRef{a, b, other, deployment}; !reflect.DeepEqual(canonical, want) {
+		t.Errorf("CanonicalManagedResources() = %v, want %v", canonical, want)
+	}
+	if again := CanonicalManagedResources(canonical); !reflect.DeepEqual(again, canonical) {
+		t.Errorf("CanonicalManagedResources() is not idempotent: %v, then %v", canonical, again)
+	}
+	if !reflect.DeepEqual(input, original) {
+		t.Errorf("CanonicalManagedResources() modified its input: %v, want %v", input, original)
+	}
+}
+
+func TestManagedResourceChurn(t *testing.T) {
+	a, b, c := configMapRef("default", "a"), configMapRef("default", "b"), configMapRef("default", "c")
+
+	tests := []struct {
+		name                            string
+		old, new                        []api.TypedObjectRef
+		wantAdded, wantRemoved, wantSet int
+	}{
+		{name: "scale up", old: []api.TypedObjectRef{a}, new: []api.TypedObjectRef{a, b, c}, wantAdded: 2, wantSet: 1},
+		{name: "scale down", old: []api.TypedObjectRef{a, b, c}, new: []api.TypedObjectRef{b}, wantRemoved: 2, wantSet: 1},
+		{name: "steady state", old: []api.TypedObjectRef{a, b}, new: []api.TypedObjectRef{b, a}, wantSet: 2},
+		{name: "duplicates are counted once", old: []api.TypedObjectRef{a, a}, new: []api.TypedObjectRef{a, b, b}, wantAdded: 1, wantSet: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed, unchanged := ManagedResourceChurn(tt.old, tt.new)
+			if added != tt.wantAdded || removed != tt.wantRemoved || unchanged != tt.wantSet {
+				t.Errorf("ManagedResourceChurn() = (%d, %d, %d), want (%d, %d, %d)",
+					added, removed, unchanged, tt.wantAdded, tt.wantRemoved, tt.wantSet)
+			}
+		})
+	}
+}
+
+func TestManagedResourcesOfKind(t *testing.T) {
+	a := configMapRef("default", "a")
+	b := configMapRef("other", "b")
+	deployment := api.TypedObjectRef{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "a"}
+
+	refs := []api.TypedObjectRef{a, deployment, b}
+	if got, want := ManagedResourcesOfKind(refs, a.GroupVersionKind()), []api.TypedObjectRef{a, b}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ManagedResourcesOfKind(ConfigMap) = %v, want %v", got, want)
+	}
+	secretGVK := schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+	if got := ManagedResourcesOfKind(refs, secretGVK); got != nil {
+		t.Errorf("ManagedResourcesOfKind(Secret) = %v, want none", got)
+	}
+}
+
+func TestManagedResourcesDrifted(t *testing.T) {
+	a, b := configMapRef("default", "a"), configMapRef("default", "b")
+
+	tests := []struct {
+		name             string
+		current, desired []api.TypedObjectRef
+		want             bool
+	}{
+		{name: "in sync", current: []api.TypedObjectRef{a, b}, desired: []api.TypedObjectRef{a, b}, want: false},
+		{name: "reordered", current: []api.TypedObjectRef{b, a}, desired: []api.TypedObjectRef{a, b}, want: false},
+		{name: "duplicated", current: []api.TypedObjectRef{a, a, b}, desired: []api.TypedObjectRef{a, b}, want: false},
+		{name: "missing", current: []api.TypedObjectRef{a}, desired: []api.TypedObjectRef{a, b}, want: true},
+		{name: "extra", current: []api.TypedObjectRef{a, b}, desired: []api.TypedObjectRef{a}, want: true},
+		{name: "both empty", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ManagedResourcesDrifted(tt.current, tt.desired); got != tt.want {
+				t.Errorf("ManagedResourcesDrifted() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+// resourceManager is a minimal ResourceManager.
+type resourceManager struct {
+	refs []api.TypedObjectRef
+}
+
+func (r *resourceManager) SetManagedResources(refs []api.TypedObjectRef) { r.refs = refs }
+func (r *resourceManager) GetManagedResources() []api.TypedObjectRef     { return r.refs }
+
+func TestManagedResourceSet(t *testing.T) {
+	a, b, c := configMapRef("default", "a"), configMapRef("default", "b"), configMapRef("default", "c")
+
+	s := NewManagedResourceSet(b, a, b)
+	if s.Len() != 2 {
+		t.Errorf("Len() = %d, want duplicates to be dropped", s.Len())
+	}
+	if s.Add(a) {
+		t.Error("Add() = true for a member")
+	}
+	if !s.Add(c) {
+		t.Error("Add() = false for a non-member")
+	}
+	if !s.Contains(c) {
+		t.Error("Contains() = false for an added member")
+	}
+	if !s.Remove(b) || s.Remove(b) {
+		t.Error("Remove() should return true only when removing a member")
+	}
+	if s.Contains(b) {
+		t.Error("Contains() = true for a removed member")
+	}
+
+	rm := &resourceManager{}
+	s.ApplyTo(rm)
+	if want := []api.TypedObjectRef{a, c}; !reflect.DeepEqual(rm.refs, want) {
+		t.Errorf("ApplyTo() set %v, want %v", rm.refs, want)
+	}
+	if got := ManagedResourceSetFrom(rm).List(); !reflect.DeepEqual(got, rm.refs) {
+		t.Errorf("ManagedResourceSetFrom().List() = %v, want %v", got, rm.refs)
+	}
+
+	var zero ManagedResourceSet
+	if zero.Len() != 0 || zero.Contains(a) || !zero.Add(a) {
+		t.Error("the zero ManagedResourceSet is not an empty set ready for use")
+	}
+}
+
+func TestShouldGC(t *testing.T) {
+	a, b := configMapRef("default", "a"), configMapRef("default", "b")
+	desired := []api.TypedObjectRef{a}
+
+	if ShouldGC(a, desired) {
+		t.Error("ShouldGC() = true for a desired ref")
+	}
+	if !ShouldGC(b, desired) {
+		t.Error("ShouldGC() = false for an orphaned ref")
+	}
+	if !ShouldGC(a, nil) {
+		t.Error("ShouldGC() = false with no desired refs")
+	}
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/reddit/achilles-sdk-api/api"
+)
+
+// IsBeingDeleted returns true if the object has a deletion timestamp.
+func IsBeingDeleted(obj client.Object) bool {
+	return obj.GetDeletionTimestamp() != nil
+}
+
+// HasFinalizer returns true if the object has the supplied finalizer.
+func HasFinalizer(obj client.Object, finalizer string) bool {
+	for _, f := range obj.GetFinalizers() {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// AddFinalizer adds the finalizer to the object, returning false if the object already has it.
+func AddFinalizer(obj client.Object, finalizer string) bool {
+	if HasFinalizer(obj, finalizer) {
+		return false
+	}
+	obj.SetFinalizers(append(obj.GetFinalizers(), finalizer))
+	return true
+}
+
+// RemoveFinalizer removes all occurrences of the finalizer from the object, returning false if the object
+// doesn't have it.
+func RemoveFinalizer(obj client.Object, finalizer string) bool {
+	if !HasFinalizer(obj, finalizer) {
+		return false
+	}
+	var finalizers []string
+	for _, f := range obj.GetFinalizers() {
+		if f != finalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	obj.SetFinalizers(finalizers)
+	return true
+}
+
+// ShouldFinalize returns true if the object is being deleted and still has the supplied finalizer,
+// i.e. the controller owning the finalizer must run its finalization logic.
+func ShouldFinalize(obj client.Object, finalizer string) bool {
+	return IsBeingDeleted(obj) && HasFinalizer(obj, finalizer)
+}
+
+// InitializeConditions sets the default conditions for a newly created resource, Ready=Creating and
+// Synced=ReconcilePending, if the resource has no conditions yet. It is a no-op for initialized resources.
+func InitializeConditions[T any, P Resource[T]](obj P) {
+	if len(obj.GetConditions()) > 0 {
+		return
+	}
+	obj.SetConditions(api.Creating(), api.ReconcilePending())
+}
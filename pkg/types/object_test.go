@@ -0,0 +1,98 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/reddit/achilles-sdk-api/api"
+)
+
+const testFinalizer = "example.com/finalizer"
+
+func TestShouldFinalize(t *testing.T) {
+	now := metav1.Now()
+
+	tests := []struct {
+		name              string
+		deletionTimestamp *metav1.Time
+		finalizers        []string
+		wantDeleted       bool
+		wantHasFinalizer  bool
+	}{
+		{name: "not deleted without finalizer"},
+		{name: "not deleted with finalizer", finalizers: []string{"other", testFinalizer}, wantHasFinalizer: true},
+		{name: "deleted without finalizer", deletionTimestamp: &now, finalizers: []string{"other"}, wantDeleted: true},
+		{name: "deleted with finalizer", deletionTimestamp: &now, finalizers: []string{testFinalizer}, wantDeleted: true, wantHasFinalizer: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				DeletionTimestamp: tt.deletionTimestamp,
+				Finalizers:        tt.finalizers,
+			}}
+			if got := IsBeingDeleted(obj); got != tt.wantDeleted {
+				t.Errorf("IsBeingDeleted() = %t, want %t", got, tt.wantDeleted)
+			}
+			if got := HasFinalizer(obj, testFinalizer); got != tt.wantHasFinalizer {
+				t.Errorf("HasFinalizer() = %t, want %t", got, tt.wantHasFinalizer)
+			}
+			if got, want := ShouldFinalize(obj, testFinalizer), tt.wantDeleted && tt.wantHasFinalizer; got != want {
+				t.Errorf("ShouldFinalize() = %t, want %t", got, want)
+			}
+		})
+	}
+}
+
+// resource is a minimal Resource.
+type resource struct {
+	corev1.ConfigMap
+	api.ConditionedStatus
+}
+
+func TestInitializeConditions(t *testing.T) {
+	t.Run("fresh", func(t *testing.T) {
+		obj := &resource{}
+		InitializeConditions(obj)
+
+		if want := api.NewConditionedStatus(api.Creating(), api.ReconcilePending()); !obj.ConditionedStatus.Equal(want) {
+			t.Errorf("InitializeConditions() conditions = %v, want %v", obj.Conditions, want.Conditions)
+		}
+	})
+
+	t.Run("already initialized", func(t *testing.T) {
+		obj := &resource{ConditionedStatus: *api.NewConditionedStatus(api.Available())}
+		InitializeConditions(obj)
+
+		if want := api.NewConditionedStatus(api.Available()); !obj.ConditionedStatus.Equal(want) {
+			t.Errorf("InitializeConditions() changed the conditions to %v, want %v", obj.Conditions, want.Conditions)
+		}
+	})
+}
+
+func TestAddAndRemoveFinalizer(t *testing.T) {
+	obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Finalizers: []string{"example.com/other"}}}
+
+	if !AddFinalizer(obj, testFinalizer) {
+		t.Error("AddFinalizer() = false for a missing finalizer")
+	}
+	if AddFinalizer(obj, testFinalizer) {
+		t.Error("AddFinalizer() = true for a present finalizer")
+	}
+	if want := []string{"example.com/other", testFinalizer}; !reflect.DeepEqual(obj.Finalizers, want) {
+		t.Errorf("finalizers = %v, want %v", obj.Finalizers, want)
+	}
+
+	obj.Finalizers = append(obj.Finalizers, testFinalizer)
+	if !RemoveFinalizer(obj, testFinalizer) {
+		t.Error("RemoveFinalizer() = false for a present finalizer")
+	}
+	if RemoveFinalizer(obj, testFinalizer) {
+		t.Error("RemoveFinalizer() = true for a missing finalizer")
+	}
+	if want := []string{"example.com/other"}; !reflect.DeepEqual(obj.Finalizers, want) {
+		t.Errorf("finalizers = %v, want all occurrences removed", obj.Finalizers)
+	}
+}
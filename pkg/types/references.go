@@ -0,0 +1,32 @@
+package types
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/reddit/achilles-sdk-api/api"
+)
+
+// CheckTypedReferences gets each referenced object and returns a ReferencesValid condition if all of them exist,
+// otherwise a ReferencesInvalidTyped condition listing the missing refs. Errors other than NotFound abort the check
+// and are returned.
+func CheckTypedReferences(ctx context.Context, c client.Client, refs []api.TypedObjectRef) (api.Condition, error) {
+	var missing []api.TypedObjectRef
+	for _, ref := range refs {
+		if err := c.Get(ctx, ref.ObjectKey(), ref.ToUnstructured()); err != nil {
+			if apierrors.IsNotFound(err) {
+				missing = append(missing, ref)
+				continue
+			}
+			return api.Condition{}, fmt.Errorf("getting %s: %w", ref, err)
+		}
+	}
+
+	if len(missing) > 0 {
+		return api.ReferencesInvalidTyped(api.ReasonReferenceNotFound, missing), nil
+	}
+	return api.ReferencesValid(), nil
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/reddit/achilles-sdk-api/api"
+)
+
+// getClient is a client.Client whose Get succeeds for the supplied existing objects, returns NotFound for other
+// objects, and returns err if it is set. Other methods are not implemented.
+type getClient struct {
+	client.Client
+	existing map[client.ObjectKey]bool
+	err      error
+}
+
+func (c *getClient) Get(_ context.Context, key client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+	if c.err != nil {
+		return c.err
+	}
+	if !c.existing[key] {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+	}
+	return nil
+}
+
+func TestCheckTypedReferences(t *testing.T) {
+	a := configMapRef("default", "a")
+	b := configMapRef("default", "b")
+	existing := map[client.ObjectKey]bool{a.ObjectKey(): true}
+
+	t.Run("all exist", func(t *testing.T) {
+		c, err := CheckTypedReferences(context.Background(), &getClient{existing: existing}, []api.TypedObjectRef{a})
+		if err != nil {
+			t.Fatalf("CheckTypedReferences() error = %v", err)
+		}
+		if c.Type != api.TypeReferencesValid || c.Status != corev1.ConditionTrue {
+			t.Errorf("CheckTypedReferences() = %v, want ReferencesValid=True", c)
+		}
+	})
+
+	t.Run("some missing", func(t *testing.T) {
+		c, err := CheckTypedReferences(context.Background(), &getClient{existing: existing}, []api.TypedObjectRef{a, b})
+		if err != nil {
+			t.Fatalf("CheckTypedReferences() error = %v", err)
+		}
+		if c.Status != corev1.ConditionFalse || c.Reason != api.ReasonReferenceNotFound {
+			t.Errorf("CheckTypedReferences() = %v, want ReferencesValid=False Reason=ReferenceNotFound", c)
+		}
+		if want := "Referenced objects are not found: " + b.String(); c.Message != want {
+			t.Errorf("message = %q, want %q", c.Message, want)
+		}
+	})
+
+	t.Run("other errors abort", func(t *testing.T) {
+		boom := errors.New("boom")
+		_, err := CheckTypedReferences(context.Background(), &getClient{err: boom}, []api.TypedObjectRef{a})
+		if !errors.Is(err, boom) {
+			t.Errorf("CheckTypedReferences() error = %v, want %v", err, boom)
+		}
+	})
+}